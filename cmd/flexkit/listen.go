@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Plasso/plasso-go/flexkit"
+)
+
+// Prints the events of the space as they happen, polling for new ones.  With
+// -forward every event is also posted to a local server, like a webhook.
+func listen(ctx context.Context, config config, args []string) error {
+	var since, interval time.Duration
+	var forward string
+	var flags = flag.NewFlagSet("webhook listen", flag.ExitOnError)
+	flags.DurationVar(&since, "since", 0, "also print events from this far back")
+	flags.DurationVar(&interval, "interval", 5*time.Second, "how often to poll for events")
+	flags.StringVar(&forward, "forward", "", "post events to this url, e.g. localhost:8080/hooks")
+	flags.Parse(args)
+
+	if forward != "" && !strings.Contains(forward, "://") {
+		forward = "http://" + forward
+	}
+
+	space, err := config.space()
+	if err != nil {
+		return err
+	}
+
+	var encoder = json.NewEncoder(os.Stdout)
+	var poller = eventPoller{space: space, since: time.Now().Add(-since), seen: map[string]bool{}}
+	for {
+		events, err := poller.poll(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			err = encoder.Encode(event)
+			if err != nil {
+				return err
+			}
+
+			if forward != "" {
+				forwardEvent(ctx, forward, event)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Posts event to url the way Plasso delivers webhooks.  Failures are reported
+// but do not stop listening, the local server may just not be up yet.
+func forwardEvent(ctx context.Context, url string, event flexkit.Event) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "flexkit: forward:", err)
+		return
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "flexkit: forward:", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Plasso-Event", string(event.Type))
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "flexkit: forward:", err)
+		return
+	}
+	res.Body.Close()
+
+	fmt.Fprintf(os.Stderr, "flexkit: forwarded %s %s: %s\n", event.Type, event.Id, res.Status)
+}
+
+// Fetches the events that happened since the last poll
+type eventPoller struct {
+	space *flexkit.SpaceClient
+	since time.Time
+	seen  map[string]bool // Ids of the events at since, which the next poll lists again
+}
+
+func (poller *eventPoller) poll(ctx context.Context) ([]flexkit.Event, error) {
+	events, err := poller.space.ListEvents(poller.since).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var fresh []flexkit.Event
+	for _, event := range events {
+		if poller.seen[event.Id] {
+			continue
+		}
+
+		if event.CreatedAt.After(poller.since) {
+			poller.since = event.CreatedAt
+			poller.seen = map[string]bool{}
+		}
+		poller.seen[event.Id] = true
+		fresh = append(fresh, event)
+	}
+
+	return fresh, nil
+}
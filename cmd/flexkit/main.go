@@ -0,0 +1,254 @@
+// Command flexkit manages a Plasso space from the command line.  It is meant
+// for debugging integrations and for scripts, every command prints JSON.
+//
+//	flexkit login -email jane@example.com -password secret
+//	flexkit member get 5f1c2a
+//	flexkit member get -email jane@example.com
+//	flexkit member list -plan pro
+//	flexkit payments list -since 24h
+//	flexkit plans list
+//	flexkit webhook listen -forward localhost:8080/hooks
+//
+// The keys of the space are read from FLEXKIT_PUBLIC_KEY and
+// FLEXKIT_SECRET_KEY, or given with the -public-key and -secret-key flags
+// before the command.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/Plasso/plasso-go/flexkit"
+)
+
+const usage string = `usage: flexkit [flags] <command> [arguments]
+
+commands:
+  login -email <email> -password <password>
+  member get <id> | member get -email <email>
+  member list [-plan <plan>] [-status <status>]
+  payments list [-since <duration>]
+  plans list
+  webhook listen [-since <duration>] [-interval <duration>] [-forward <url>]
+
+flags:
+`
+
+// Settings shared by all commands
+type config struct {
+	publicKey string
+	secretKey string
+	baseURL   string
+}
+
+func main() {
+	var config config
+	var flags = flag.NewFlagSet("flexkit", flag.ExitOnError)
+	flags.StringVar(&config.publicKey, "public-key", os.Getenv("FLEXKIT_PUBLIC_KEY"), "public key of the space")
+	flags.StringVar(&config.secretKey, "secret-key", os.Getenv("FLEXKIT_SECRET_KEY"), "secret key of the space")
+	flags.StringVar(&config.baseURL, "base-url", os.Getenv("FLEXKIT_BASE_URL"), "Plasso API url, defaults to the production API")
+	flags.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flags.PrintDefaults()
+	}
+	flags.Parse(os.Args[1:])
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var err = run(ctx, config, flags.Args())
+	if errors.Is(err, errUsage) {
+		flags.Usage()
+		os.Exit(2)
+	}
+	if err != nil && !errors.Is(err, context.Canceled) {
+		fmt.Fprintln(os.Stderr, "flexkit:", err)
+		os.Exit(1)
+	}
+}
+
+// Returned for unknown commands and missing arguments
+var errUsage = errors.New("usage")
+
+func run(ctx context.Context, config config, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	var command = args[0]
+	if len(args) > 1 && args[0] != "login" {
+		command += " " + args[1]
+		args = args[1:]
+	}
+
+	switch command {
+	case "login":
+		return login(ctx, config, args[1:])
+	case "member get":
+		return getMember(ctx, config, args[1:])
+	case "member list":
+		return listMembers(ctx, config, args[1:])
+	case "payments list":
+		return listPayments(ctx, config, args[1:])
+	case "plans list":
+		return listPlans(ctx, config, args[1:])
+	case "webhook listen":
+		return listen(ctx, config, args[1:])
+	}
+
+	return errUsage
+}
+
+// Returns a client for calls with the public key
+func (config config) client() *flexkit.Client {
+	return flexkit.NewClient(config.options()...)
+}
+
+// Returns a client for space owner calls, which need the secret key
+func (config config) space() (*flexkit.SpaceClient, error) {
+	if config.secretKey == "" {
+		return nil, errors.New("this command needs the secret key, set FLEXKIT_SECRET_KEY or -secret-key")
+	}
+
+	return flexkit.NewSpaceClient(config.secretKey, config.options()...), nil
+}
+
+func (config config) options() []flexkit.Option {
+	var options = []flexkit.Option{flexkit.WithAppInfo("flexkit-cli", flexkit.Version())}
+	if config.publicKey != "" {
+		options = append(options, flexkit.WithPublicKey(config.publicKey))
+	}
+	if config.baseURL != "" {
+		options = append(options, flexkit.WithBaseURL(config.baseURL))
+	}
+
+	return options
+}
+
+func login(ctx context.Context, config config, args []string) error {
+	var request = flexkit.LoginRequest{PublicKey: config.publicKey}
+	var flags = flag.NewFlagSet("login", flag.ExitOnError)
+	flags.StringVar(&request.Email, "email", "", "email of the member")
+	flags.StringVar(&request.Password, "password", "", "password of the member")
+	flags.Parse(args)
+
+	var err = request.Validate()
+	if err != nil {
+		return err
+	}
+
+	member, err := config.client().WithContext(ctx).Login(request)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(map[string]string{"token": member.TokenValue()})
+}
+
+func getMember(ctx context.Context, config config, args []string) error {
+	var email string
+	var flags = flag.NewFlagSet("member get", flag.ExitOnError)
+	flags.StringVar(&email, "email", "", "look the member up by email instead of id")
+	flags.Parse(args)
+
+	space, err := config.space()
+	if err != nil {
+		return err
+	}
+	space = space.WithContext(ctx)
+
+	var member *flexkit.MemberData
+	switch {
+	case email != "":
+		member, err = space.FindMemberByEmail(email)
+	case flags.NArg() == 1:
+		member, err = space.GetMemberByID(flags.Arg(0))
+	default:
+		return errUsage
+	}
+	if err != nil {
+		return err
+	}
+
+	return printJSON(member)
+}
+
+func listMembers(ctx context.Context, config config, args []string) error {
+	var options flexkit.ListMembersOptions
+	var flags = flag.NewFlagSet("member list", flag.ExitOnError)
+	flags.StringVar(&options.Plan, "plan", "", "only list members of this plan")
+	flags.StringVar(&options.Status, "status", "", "only list members with this status")
+	flags.Parse(args)
+
+	space, err := config.space()
+	if err != nil {
+		return err
+	}
+
+	members, err := space.ListMembers(options).All(ctx)
+	if err != nil {
+		return err
+	}
+
+	return printLines(members)
+}
+
+// Lists payments from the payment events of the space
+func listPayments(ctx context.Context, config config, args []string) error {
+	var since time.Duration
+	var flags = flag.NewFlagSet("payments list", flag.ExitOnError)
+	flags.DurationVar(&since, "since", 30*24*time.Hour, "how far back to list payments")
+	flags.Parse(args)
+
+	space, err := config.space()
+	if err != nil {
+		return err
+	}
+
+	var types = []flexkit.EventType{flexkit.EventPaymentSucceeded, flexkit.EventPaymentFailed, flexkit.EventPaymentRefunded}
+	events, err := space.ListEvents(time.Now().Add(-since), types...).All(ctx)
+	if err != nil {
+		return err
+	}
+
+	return printLines(events)
+}
+
+func listPlans(ctx context.Context, config config, args []string) error {
+	if len(args) > 0 {
+		return errUsage
+	}
+
+	space, err := config.client().WithContext(ctx).GetSpace()
+	if err != nil {
+		return err
+	}
+
+	return printLines(space.Plans)
+}
+
+// Prints value as indented JSON
+func printJSON(value interface{}) error {
+	var encoder = json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// Prints every item as JSON on its own line
+func printLines[T any](items []T) error {
+	var encoder = json.NewEncoder(os.Stdout)
+	for _, item := range items {
+		var err = encoder.Encode(item)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,213 @@
+package flexkit
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// A postal address, used for billing and shipping
+type Address struct {
+	Name    string // Name of the recipient, only sent for shipping addresses
+	Street  string // Street address
+	City    string // City
+	State   string // State or region
+	Zip     string // Zip or postal code
+	Country string // Country
+}
+
+// Reports whether no part of the address is set
+func (address Address) IsZero() bool {
+	return address == Address{}
+}
+
+// Checks the street, city, zip and country of the address are set.  Every
+// missing part is reported, joined into one error.
+func (address Address) Validate() error {
+	var errs []error
+
+	if address.Street == "" {
+		errs = append(errs, errors.New("address: street is required"))
+	}
+	if address.City == "" {
+		errs = append(errs, errors.New("address: city is required"))
+	}
+	if address.Zip == "" {
+		errs = append(errs, errors.New("address: zip is required"))
+	}
+	if address.Country == "" {
+		errs = append(errs, errors.New("address: country is required"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// The flat billing fields the API expects
+type billingFields struct {
+	BillingAddress string `json:"billing_address"`
+	BillingCity    string `json:"billing_city"`
+	BillingState   string `json:"billing_state"`
+	BillingZip     string `json:"billing_zip"`
+	BillingCountry string `json:"billing_country"`
+}
+
+// The flat shipping fields the API expects
+type shippingFields struct {
+	ShippingName    string `json:"shipping_name"`
+	ShippingAddress string `json:"shipping_address"`
+	ShippingCity    string `json:"shipping_city"`
+	ShippingState   string `json:"shipping_state"`
+	ShippingZip     string `json:"shipping_zip"`
+	ShippingCountry string `json:"shipping_country"`
+}
+
+func (address Address) billingFields() billingFields {
+	return billingFields{
+		BillingAddress: address.Street,
+		BillingCity:    address.City,
+		BillingState:   address.State,
+		BillingZip:     address.Zip,
+		BillingCountry: address.Country,
+	}
+}
+
+func (address Address) shippingFields() shippingFields {
+	return shippingFields{
+		ShippingName:    address.Name,
+		ShippingAddress: address.Street,
+		ShippingCity:    address.City,
+		ShippingState:   address.State,
+		ShippingZip:     address.Zip,
+		ShippingCountry: address.Country,
+	}
+}
+
+func (fields billingFields) address() Address {
+	return Address{
+		Street:  fields.BillingAddress,
+		City:    fields.BillingCity,
+		State:   fields.BillingState,
+		Zip:     fields.BillingZip,
+		Country: fields.BillingCountry,
+	}
+}
+
+func (fields shippingFields) address() Address {
+	return Address{
+		Name:    fields.ShippingName,
+		Street:  fields.ShippingAddress,
+		City:    fields.ShippingCity,
+		State:   fields.ShippingState,
+		Zip:     fields.ShippingZip,
+		Country: fields.ShippingCountry,
+	}
+}
+
+// Sends the addresses as the flat billing_* and shipping_* fields of the API
+func (request PaymentRequest) MarshalJSON() ([]byte, error) {
+	type plain PaymentRequest
+	return json.Marshal(struct {
+		plain
+		billingFields
+		shippingFields
+	}{plain(request), request.Billing.billingFields(), request.Shipping.shippingFields()})
+}
+
+func (request *PaymentRequest) UnmarshalJSON(data []byte) error {
+	type plain PaymentRequest
+	var wire struct {
+		plain
+		billingFields
+		shippingFields
+	}
+
+	var err = json.Unmarshal(data, &wire)
+	if err != nil {
+		return err
+	}
+
+	*request = PaymentRequest(wire.plain)
+	request.Billing = wire.billingFields.address()
+	request.Shipping = wire.shippingFields.address()
+	return nil
+}
+
+// Sends the addresses as the flat billing_* and shipping_* fields of the API
+func (request SubscriptionRequest) MarshalJSON() ([]byte, error) {
+	type plain SubscriptionRequest
+	return json.Marshal(struct {
+		plain
+		billingFields
+		shippingFields
+	}{plain(request), request.Billing.billingFields(), request.Shipping.shippingFields()})
+}
+
+func (request *SubscriptionRequest) UnmarshalJSON(data []byte) error {
+	type plain SubscriptionRequest
+	var wire struct {
+		plain
+		billingFields
+		shippingFields
+	}
+
+	var err = json.Unmarshal(data, &wire)
+	if err != nil {
+		return err
+	}
+
+	*request = SubscriptionRequest(wire.plain)
+	request.Billing = wire.billingFields.address()
+	request.Shipping = wire.shippingFields.address()
+	return nil
+}
+
+// Sends the address as the flat shipping_* fields of the API
+func (request SettingsRequest) MarshalJSON() ([]byte, error) {
+	type plain SettingsRequest
+	return json.Marshal(struct {
+		plain
+		shippingFields
+	}{plain(request), request.Shipping.shippingFields()})
+}
+
+func (request *SettingsRequest) UnmarshalJSON(data []byte) error {
+	type plain SettingsRequest
+	var wire struct {
+		plain
+		shippingFields
+	}
+
+	var err = json.Unmarshal(data, &wire)
+	if err != nil {
+		return err
+	}
+
+	*request = SettingsRequest(wire.plain)
+	request.Shipping = wire.shippingFields.address()
+	return nil
+}
+
+// Sends the address as the flat billing_* fields of the API
+func (request GiftRequest) MarshalJSON() ([]byte, error) {
+	type plain GiftRequest
+	return json.Marshal(struct {
+		plain
+		billingFields
+	}{plain(request), request.Billing.billingFields()})
+}
+
+func (request *GiftRequest) UnmarshalJSON(data []byte) error {
+	type plain GiftRequest
+	var wire struct {
+		plain
+		billingFields
+	}
+
+	var err = json.Unmarshal(data, &wire)
+	if err != nil {
+		return err
+	}
+
+	*request = GiftRequest(wire.plain)
+	request.Billing = wire.billingFields.address()
+	return nil
+}
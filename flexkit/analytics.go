@@ -0,0 +1,180 @@
+package flexkit
+
+import (
+	"errors"
+	"time"
+)
+
+// Intervals of a revenue report
+const (
+	IntervalDay   = "day"
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+)
+
+const revenueReportQuery string = `
+query revenueReport($start: String, $end: String, $interval: String) {
+  revenueReport(start: $start, end: $end, interval: $interval) {
+    currency,
+    gross,
+    refunds,
+    net,
+    intervals {
+      start,
+      gross,
+      refunds,
+      net
+    }
+  }
+}`
+
+const subscriptionMetricsQuery string = `
+query subscriptionMetrics {
+  subscriptionMetrics {
+    currency,
+    mrr,
+    churnRate,
+    activeMembers,
+    plans {
+      plan,
+      activeMembers,
+      mrr
+    }
+  }
+}`
+
+// The time range of a revenue report
+type ReportPeriod struct {
+	Start    time.Time // Start of the report, inclusive
+	End      time.Time // End of the report, exclusive
+	Interval string    // One of the Interval* values, defaults to IntervalDay
+}
+
+// Revenue of the space over a period
+type RevenueReport struct {
+	Gross     Money             // Payments received
+	Refunds   Money             // Payments refunded
+	Net       Money             // Gross minus refunds
+	Intervals []RevenueInterval // Revenue per interval of the period
+}
+
+// Revenue of one interval of a RevenueReport
+type RevenueInterval struct {
+	Start   time.Time // Start of the interval
+	Gross   Money     // Payments received
+	Refunds Money     // Payments refunded
+	Net     Money     // Gross minus refunds
+}
+
+// Subscription numbers of the space as of now
+type SubscriptionMetrics struct {
+	MRR           Money         // Monthly recurring revenue
+	ChurnRate     float64       // Share of subscriptions cancelled over the last 30 days, 0 to 1
+	ActiveMembers int           // Members with an active or trialing subscription
+	Plans         []PlanMetrics // The numbers per plan
+}
+
+// Subscription numbers of one plan
+type PlanMetrics struct {
+	Plan          string // Plan ID
+	ActiveMembers int    // Members with an active or trialing subscription
+	MRR           Money  // Monthly recurring revenue
+}
+
+type revenueNode struct {
+	Start   time.Time `json:"start"`
+	Gross   string    `json:"gross"`
+	Refunds string    `json:"refunds"`
+	Net     string    `json:"net"`
+}
+
+// Get the revenue of the space over a period
+func (space *SpaceClient) GetRevenueReport(period ReportPeriod) (*RevenueReport, error) {
+	var response struct {
+		Report struct {
+			Currency  string        `json:"currency"`
+			Intervals []revenueNode `json:"intervals"`
+			revenueNode
+		} `json:"revenueReport"`
+	}
+	if period.Interval == "" {
+		period.Interval = IntervalDay
+	}
+	var variables = map[string]interface{}{
+		"start":    period.Start.UTC().Format(time.RFC3339),
+		"end":      period.End.UTC().Format(time.RFC3339),
+		"interval": period.Interval,
+	}
+
+	var err = space.client.GraphQL(space.client.context(), revenueReportQuery, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	var currency = response.Report.Currency
+	total, err := response.Report.revenueNode.interval(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	var report = RevenueReport{Gross: total.Gross, Refunds: total.Refunds, Net: total.Net}
+	for _, node := range response.Report.Intervals {
+		interval, err := node.interval(currency)
+		if err != nil {
+			return nil, err
+		}
+		report.Intervals = append(report.Intervals, interval)
+	}
+
+	return &report, nil
+}
+
+func (node *revenueNode) interval(currency string) (RevenueInterval, error) {
+	var interval = RevenueInterval{Start: node.Start}
+	var err = errors.Join(
+		parseAmount(node.Gross, currency, &interval.Gross),
+		parseAmount(node.Refunds, currency, &interval.Refunds),
+		parseAmount(node.Net, currency, &interval.Net))
+
+	return interval, err
+}
+
+// Get the MRR, churn and active members of the space, in total and per plan
+func (space *SpaceClient) GetSubscriptionMetrics() (*SubscriptionMetrics, error) {
+	var response struct {
+		Metrics struct {
+			Currency      string  `json:"currency"`
+			MRR           string  `json:"mrr"`
+			ChurnRate     float64 `json:"churnRate"`
+			ActiveMembers int     `json:"activeMembers"`
+			Plans         []struct {
+				Plan          string `json:"plan"`
+				ActiveMembers int    `json:"activeMembers"`
+				MRR           string `json:"mrr"`
+			} `json:"plans"`
+		} `json:"subscriptionMetrics"`
+	}
+
+	var err = space.client.GraphQL(space.client.context(), subscriptionMetricsQuery, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	var node = response.Metrics
+	var metrics = SubscriptionMetrics{ChurnRate: node.ChurnRate, ActiveMembers: node.ActiveMembers}
+	err = parseAmount(node.MRR, node.Currency, &metrics.MRR)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, plan := range node.Plans {
+		var planMetrics = PlanMetrics{Plan: plan.Plan, ActiveMembers: plan.ActiveMembers}
+		err = parseAmount(plan.MRR, node.Currency, &planMetrics.MRR)
+		if err != nil {
+			return nil, err
+		}
+		metrics.Plans = append(metrics.Plans, planMetrics)
+	}
+
+	return &metrics, nil
+}
@@ -0,0 +1,73 @@
+package flexkit
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// Returned without contacting the API while the circuit breaker is open
+var ErrCircuitOpen = errors.New("flexkit: circuit breaker open, Plasso API unavailable")
+
+// Stops calling the API for coolDown after threshold calls in a row failed
+// with a network error or a 5xx status.  Calls fail fast with ErrCircuitOpen
+// in that time, instead of each waiting for its timeout while Plasso is down.
+// After the cool-down one call is let through to check whether the API is back.
+// Panics if threshold is not positive.
+func WithCircuitBreaker(threshold int, coolDown time.Duration) Option {
+	if threshold <= 0 {
+		panic("flexkit: WithCircuitBreaker threshold must be positive")
+	}
+
+	return func(client *Client) {
+		client.breaker = &circuitBreaker{threshold: threshold, coolDown: coolDown}
+	}
+}
+
+// Shared by the copies WithContext makes of a client
+type circuitBreaker struct {
+	mutex     sync.Mutex
+	threshold int
+	coolDown  time.Duration
+	failures  int       // Calls in a row that failed
+	openedAt  time.Time // When failures reached threshold
+	probing   bool      // Whether a call is checking whether the API is back
+}
+
+// Reports whether a call may be made
+func (breaker *circuitBreaker) allow() bool {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	if breaker.failures < breaker.threshold {
+		return true
+	}
+	if breaker.probing || time.Since(breaker.openedAt) < breaker.coolDown {
+		return false
+	}
+
+	breaker.probing = true
+	return true
+}
+
+// Records the outcome of a call allowed by allow.  Calls canceled by the
+// caller, or past the caller's own deadline, say nothing about the API and are
+// not counted.  Calls that ran out of the client's timeout are failures.
+func (breaker *circuitBreaker) record(canceled bool, failed bool) {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	breaker.probing = false
+	if canceled {
+		return
+	}
+	if !failed {
+		breaker.failures = 0
+		return
+	}
+
+	breaker.failures++
+	if breaker.failures >= breaker.threshold {
+		breaker.openedAt = time.Now()
+	}
+}
@@ -0,0 +1,160 @@
+package flexkit
+
+import (
+	"context"
+	"errors"
+	"strconv"
+)
+
+// A shopping cart in the space set with WithPublicKey.  Fill in the items and
+// customer details, show the customer Price, then Checkout.  A cart is not
+// safe for concurrent use.
+type Cart struct {
+	Email           string  // Email customer provided
+	Name            string  // Name of customer
+	Billing         Address // Billing address of customer (optional depending on products).
+	Shipping        Address // Shipping address of customer (optional depending on products).
+	ShippingOptions string  // Shipping options of customer (optional depending on products).
+
+	items  []Product
+	coupon string
+	client *Client
+}
+
+// The price of a cart, calculated by the server
+type CartPrice struct {
+	Subtotal Money // Total of the items
+	Discount Money // Taken off by the coupon
+	Shipping Money // Cost of shipping
+	Tax      Money // Tax charged
+	Total    Money // Amount that will be charged
+}
+
+type cartPriceRequest struct {
+	PublicKey       string    `json:"public_key"`
+	Products        []Product `json:"products"`
+	Coupon          string    `json:"coupon"`
+	ShippingOptions string    `json:"shipping_options"`
+	billingFields
+	shippingFields
+}
+
+type cartPriceResponse struct {
+	Currency string `json:"currency"`
+	Subtotal string `json:"subtotal"`
+	Discount string `json:"discount"`
+	Shipping string `json:"shipping"`
+	Tax      string `json:"tax"`
+	Total    string `json:"total"`
+}
+
+// Creates an empty cart
+func (c *Client) NewCart() *Cart {
+	return &Cart{client: c}
+}
+
+// Adds quantity of a product to the cart.  A negative quantity takes units
+// off, and the product is removed once none are left.
+func (cart *Cart) AddItem(productID string, quantity int) {
+	for i, item := range cart.items {
+		if item.Id == productID {
+			var current, _ = strconv.Atoi(item.Qty)
+			if current+quantity <= 0 {
+				cart.RemoveItem(productID)
+				return
+			}
+
+			cart.items[i].Qty = strconv.Itoa(current + quantity)
+			return
+		}
+	}
+
+	if quantity <= 0 {
+		return
+	}
+
+	cart.items = append(cart.items, Product{Id: productID, Qty: strconv.Itoa(quantity)})
+}
+
+// Removes a product from the cart
+func (cart *Cart) RemoveItem(productID string) {
+	var items = cart.items[:0]
+	for _, item := range cart.items {
+		if item.Id != productID {
+			items = append(items, item)
+		}
+	}
+	cart.items = items
+}
+
+// Returns the products in the cart
+func (cart *Cart) Items() []Product {
+	return append([]Product(nil), cart.items...)
+}
+
+// Applies a coupon code to the cart, replacing any earlier one.  An invalid
+// code is reported by Price and Checkout.
+func (cart *Cart) ApplyCoupon(code string) {
+	cart.coupon = code
+}
+
+// Calculates the price of the cart, including discounts, shipping and tax
+func (cart *Cart) Price(ctx context.Context) (*CartPrice, error) {
+	if len(cart.items) == 0 {
+		return nil, errors.New("flexkit: cart is empty")
+	}
+
+	var request = cartPriceRequest{
+		PublicKey:       cart.client.getPublicKey(),
+		Products:        cart.items,
+		Coupon:          cart.coupon,
+		ShippingOptions: cart.ShippingOptions,
+		billingFields:   cart.Billing.billingFields(),
+		shippingFields:  cart.Shipping.shippingFields(),
+	}
+
+	body, err := cart.client.send(ctx, "POST", "/api/cart/price", nil, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var response cartPriceResponse
+	err = cart.client.decode(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	var price CartPrice
+	err = errors.Join(
+		parseAmount(response.Subtotal, response.Currency, &price.Subtotal),
+		parseAmount(response.Discount, response.Currency, &price.Discount),
+		parseAmount(response.Shipping, response.Currency, &price.Shipping),
+		parseAmount(response.Tax, response.Currency, &price.Tax),
+		parseAmount(response.Total, response.Currency, &price.Total))
+	if err != nil {
+		return nil, err
+	}
+
+	return &price, nil
+}
+
+// Pays for the cart with a token returned from the javascript flexkit GetToken
+// call.  Like CreatePayment it returns an ActionRequiredError when the customer
+// has to complete an authentication challenge.
+func (cart *Cart) Checkout(token string) error {
+	if len(cart.items) == 0 {
+		return errors.New("flexkit: cart is empty")
+	}
+
+	return cart.client.CreatePayment(PaymentRequest{
+		PublicKey:       cart.client.getPublicKey(),
+		Token:           token,
+		Products:        cart.Items(),
+		Billing:         cart.Billing,
+		Shipping:        cart.Shipping,
+		ShippingOptions: cart.ShippingOptions,
+		Coupon:          cart.coupon,
+		Email:           cart.Email,
+		Name:            cart.Name,
+	})
+}
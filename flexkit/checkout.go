@@ -0,0 +1,54 @@
+package flexkit
+
+import (
+	"errors"
+	"time"
+)
+
+// The structure that should be filled out and passed to the CreateCheckoutSession function.
+// Set either Plan or Products.
+type CheckoutRequest struct {
+	Plan           string    `json:"plan,omitempty"`     // The plan id to subscribe the customer to
+	Products       []Product `json:"products,omitempty"` // List of products to buy
+	Email          string    `json:"email,omitempty"`    // Prefills the email of the customer (optional)
+	Coupon         string    `json:"coupon,omitempty"`   // Coupon code (optional)
+	SuccessURL     string    `json:"success_url"`        // Where the customer is sent after paying
+	CancelURL      string    `json:"cancel_url"`         // Where the customer is sent when they go back
+	IdempotencyKey string    `json:"-"`                  // Retries with the same key create one session (optional)
+}
+
+// A checkout hosted by Plasso
+type CheckoutSession struct {
+	Id        string    `json:"id"`         // Plasso checkout session id
+	URL       string    `json:"url"`        // Page to send the customer to
+	ExpiresAt time.Time `json:"expires_at"` // When the page stops accepting payment
+}
+
+type checkoutSessionRequest struct {
+	PublicKey string `json:"public_key"`
+	CheckoutRequest
+}
+
+// Creates a checkout hosted by Plasso in the space set with WithPublicKey.
+// Send the customer to the URL of the session, they are sent back to
+// SuccessURL once they paid, so no card token has to be handled server side.
+func (c *Client) CreateCheckoutSession(request CheckoutRequest) (*CheckoutSession, error) {
+	if request.Plan == "" && len(request.Products) == 0 {
+		return nil, errors.New("flexkit: checkout needs a plan or products")
+	}
+
+	var wire = checkoutSessionRequest{PublicKey: c.getPublicKey(), CheckoutRequest: request}
+
+	body, err := c.send(c.context(), "POST", "/api/checkout/sessions", c.idempotencyHeader(request.IdempotencyKey), wire)
+	if err != nil {
+		return nil, err
+	}
+
+	var session CheckoutSession
+	err = c.decode(body, &session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
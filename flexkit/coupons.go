@@ -0,0 +1,201 @@
+package flexkit
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// How long a coupon discounts a subscription
+const (
+	CouponOnce      = "once"      // Only the first payment
+	CouponRepeating = "repeating" // The first DurationMonths months
+	CouponForever   = "forever"   // Every payment
+)
+
+// The coupon fields fetched by coupon queries
+const couponSelection string = `
+      code,
+      percentOff,
+      amountOff,
+      currency,
+      duration,
+      durationMonths,
+      maxRedemptions,
+      redemptions,
+      discountTotal,
+      expiresAt,
+      expired,
+      createdAt`
+
+const listCouponsQuery string = `
+query listCoupons($first: Int, $after: String) {
+  coupons(first: $first, after: $after) {
+    nodes {` + couponSelection + `
+    },
+    pageInfo {
+      endCursor,
+      hasNextPage
+    }
+  }
+}`
+
+// A discount code of the space
+type Coupon struct {
+	Code           string    // Code customers enter
+	PercentOff     int       // Percentage taken off, 0 when AmountOff is set
+	AmountOff      Money     // Amount taken off, zero when PercentOff is set
+	Duration       string    // One of the Coupon* durations
+	DurationMonths int       // Months discounted when Duration is CouponRepeating
+	MaxRedemptions int       // How often the coupon can be used, 0 for no limit
+	Redemptions    int       // How often the coupon was used
+	DiscountTotal  Money     // Total discount given with the coupon
+	ExpiresAt      time.Time // When the coupon stops working, zero for never
+	Expired        bool      // Whether the coupon no longer works
+	CreatedAt      time.Time // When the coupon was created
+}
+
+// The structure that should be filled out and passed to the CreateCoupon function.
+// Set either PercentOff or AmountOff.
+type CouponRequest struct {
+	Code           string    // Code customers enter, generated when empty
+	PercentOff     int       // Percentage taken off
+	AmountOff      Money     // Amount taken off
+	Duration       string    // One of the Coupon* durations, defaults to CouponOnce
+	DurationMonths int       // Months discounted when Duration is CouponRepeating
+	MaxRedemptions int       // How often the coupon can be used, 0 for no limit
+	ExpiresAt      time.Time // When the coupon stops working, zero for never
+	Plans          []string  // Plans the coupon applies to, all when empty
+}
+
+type couponRequest struct {
+	Code           string     `json:"code,omitempty"`
+	PercentOff     int        `json:"percent_off,omitempty"`
+	AmountOff      string     `json:"amount_off,omitempty"`
+	Currency       string     `json:"currency,omitempty"`
+	Duration       string     `json:"duration,omitempty"`
+	DurationMonths int        `json:"duration_months,omitempty"`
+	MaxRedemptions int        `json:"max_redemptions,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	Plans          []string   `json:"plans,omitempty"`
+}
+
+type couponNode struct {
+	Code           string    `json:"code"`
+	PercentOff     int       `json:"percentOff"`
+	AmountOff      string    `json:"amountOff"`
+	Currency       string    `json:"currency"`
+	Duration       string    `json:"duration"`
+	DurationMonths int       `json:"durationMonths"`
+	MaxRedemptions int       `json:"maxRedemptions"`
+	Redemptions    int       `json:"redemptions"`
+	DiscountTotal  string    `json:"discountTotal"`
+	ExpiresAt      time.Time `json:"expiresAt"`
+	Expired        bool      `json:"expired"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// Creates a coupon
+func (space *SpaceClient) CreateCoupon(request CouponRequest) (*Coupon, error) {
+	var wire = couponRequest{
+		Code:           request.Code,
+		PercentOff:     request.PercentOff,
+		Duration:       request.Duration,
+		DurationMonths: request.DurationMonths,
+		MaxRedemptions: request.MaxRedemptions,
+		Plans:          request.Plans,
+	}
+	if !request.AmountOff.IsZero() {
+		wire.AmountOff = request.AmountOff.Decimal()
+		wire.Currency = request.AmountOff.Currency
+	}
+	if !request.ExpiresAt.IsZero() {
+		wire.ExpiresAt = &request.ExpiresAt
+	}
+
+	body, err := space.client.sendRequest("POST", "/api/space/coupons?action=create", wire)
+	if err != nil {
+		return nil, err
+	}
+
+	var node couponNode
+	err = space.client.decode(body, &node)
+	if err != nil {
+		return nil, err
+	}
+
+	coupon, err := node.coupon()
+	if err != nil {
+		return nil, err
+	}
+
+	return &coupon, nil
+}
+
+// Lists the coupons of the space with their redemptions, newest first.
+// Coupons whose amounts cannot be read are left out and logged with WithLogger.
+func (space *SpaceClient) ListCoupons() *Pager[Coupon] {
+	return newPager(space.client.context(), "", func(ctx context.Context, cursor string) ([]Coupon, string, bool, error) {
+		var response struct {
+			Coupons struct {
+				Nodes    []couponNode `json:"nodes"`
+				PageInfo pageInfo     `json:"pageInfo"`
+			} `json:"coupons"`
+		}
+		var variables = map[string]interface{}{"first": defaultPageSize, "after": cursor}
+
+		var err = space.client.GraphQL(ctx, listCouponsQuery, variables, &response)
+		if err != nil {
+			return nil, "", false, err
+		}
+
+		var coupons = make([]Coupon, 0, len(response.Coupons.Nodes))
+		for _, node := range response.Coupons.Nodes {
+			coupon, err := node.coupon()
+			if err != nil {
+				space.client.logSkipped("coupon", node.Code, err)
+				continue
+			}
+			coupons = append(coupons, coupon)
+		}
+
+		var page = response.Coupons.PageInfo
+		return coupons, page.EndCursor, page.HasNextPage, nil
+	})
+}
+
+// Stops a coupon from working.  Subscriptions already using it keep their
+// discount.
+func (space *SpaceClient) ExpireCoupon(code string) error {
+	var request = couponRequest{Code: code}
+
+	_, err := space.client.sendRequest("POST", "/api/space/coupons?action=expire", request)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (node *couponNode) coupon() (Coupon, error) {
+	var coupon = Coupon{
+		Code:           node.Code,
+		PercentOff:     node.PercentOff,
+		Duration:       node.Duration,
+		DurationMonths: node.DurationMonths,
+		MaxRedemptions: node.MaxRedemptions,
+		Redemptions:    node.Redemptions,
+		ExpiresAt:      node.ExpiresAt,
+		Expired:        node.Expired,
+		CreatedAt:      node.CreatedAt,
+	}
+
+	var err = errors.Join(
+		parseAmount(node.AmountOff, node.Currency, &coupon.AmountOff),
+		parseAmount(node.DiscountTotal, node.Currency, &coupon.DiscountTotal))
+	if err != nil {
+		return Coupon{}, err
+	}
+
+	return coupon, nil
+}
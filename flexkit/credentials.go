@@ -0,0 +1,159 @@
+package flexkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// The keys of a space
+type Credentials struct {
+	PublicKey string `json:"public_key"` // Public key, used where requests leave PublicKey empty
+	SecretKey string `json:"secret_key"` // Secret key, only used by SpaceClient
+}
+
+// Supplies the keys of a space.  The client asks for them on every request, so
+// a provider that returns new keys rotates them without a restart.
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// Sets where the client gets its keys from.  The public key is filled into
+// requests that leave PublicKey empty, and a SpaceClient created with an empty
+// secret key authenticates with the secret key of the provider.
+//
+//	var space = flexkit.NewSpaceClient("", flexkit.WithCredentials(flexkit.EnvCredentials()))
+func WithCredentials(provider CredentialsProvider) Option {
+	return func(client *Client) {
+		client.credentials = provider
+	}
+}
+
+type staticCredentials Credentials
+
+// Returns a provider that always returns the given keys
+func StaticCredentials(publicKey string, secretKey string) CredentialsProvider {
+	return staticCredentials{PublicKey: publicKey, SecretKey: secretKey}
+}
+
+func (credentials staticCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	return Credentials(credentials), nil
+}
+
+type envCredentials struct{}
+
+// Returns a provider that reads the keys from the PLASSO_PUBLIC_KEY and
+// PLASSO_SECRET_KEY environment variables on every request
+func EnvCredentials() CredentialsProvider {
+	return envCredentials{}
+}
+
+func (envCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	return Credentials{
+		PublicKey: os.Getenv("PLASSO_PUBLIC_KEY"),
+		SecretKey: os.Getenv("PLASSO_SECRET_KEY"),
+	}, nil
+}
+
+type fileCredentials struct {
+	path        string
+	mutex       sync.Mutex
+	modified    time.Time
+	credentials Credentials
+}
+
+// Returns a provider that reads the keys from a JSON file such as
+//
+//	{"public_key": "...", "secret_key": "..."}
+//
+// The file is read again whenever it changes, so rotating a key only needs the
+// file to be rewritten.
+func FileCredentials(path string) CredentialsProvider {
+	return &fileCredentials{path: path}
+}
+
+func (file *fileCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	file.mutex.Lock()
+	defer file.mutex.Unlock()
+
+	info, err := os.Stat(file.path)
+	if err != nil {
+		return Credentials{}, err
+	}
+	if info.ModTime().Equal(file.modified) {
+		return file.credentials, nil
+	}
+
+	data, err := os.ReadFile(file.path)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	var credentials Credentials
+	err = json.Unmarshal(data, &credentials)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("flexkit: credentials file %s: %w", file.path, err)
+	}
+
+	file.credentials = credentials
+	file.modified = info.ModTime()
+	return credentials, nil
+}
+
+// Returns the public key of the provider, or the one set with WithPublicKey.
+// Errors of the provider are reported by newRequest.
+func (c *Client) getPublicKey() string {
+	if c.credentials != nil {
+		credentials, err := c.credentials.Credentials(c.context())
+		if err == nil && credentials.PublicKey != "" {
+			return credentials.PublicKey
+		}
+	}
+
+	return c.publicKey
+}
+
+// Returns publicKey, or the public key of the client when it is empty
+func (c *Client) requestPublicKey(publicKey string) string {
+	if publicKey != "" {
+		return publicKey
+	}
+
+	return c.getPublicKey()
+}
+
+// Checks the provider works and authenticates req with the secret key if the
+// client is a SpaceClient.  The secret key given to NewSpaceClient takes
+// precedence over the one of the provider.
+func (c *Client) authenticate(req *http.Request, body []byte) error {
+	var secretKey = c.secretKey
+	if c.credentials != nil {
+		credentials, err := c.credentials.Credentials(req.Context())
+		if err != nil {
+			return fmt.Errorf("flexkit: credentials: %w", err)
+		}
+		if secretKey == "" {
+			secretKey = credentials.SecretKey
+		}
+	}
+
+	if !c.spaceAuth {
+		return nil
+	}
+	if secretKey == "" {
+		return errors.New("flexkit: credentials: no secret key")
+	}
+
+	if c.signing {
+		signRequest(req, body, secretKey, time.Now())
+		return nil
+	}
+
+	req.Header.Set("Authorization", "Bearer "+secretKey)
+	return nil
+}
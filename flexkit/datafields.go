@@ -0,0 +1,219 @@
+package flexkit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Types of data fields
+const (
+	DataFieldText     = "text"
+	DataFieldNumber   = "number"
+	DataFieldCheckbox = "checkbox"
+	DataFieldSelect   = "select"
+	DataFieldDate     = "date"
+)
+
+// The layout of date data field values
+const DataFieldDateLayout string = "2006-01-02"
+
+const listDataFieldsQuery string = `
+query listDataFields($publicKey: String) {
+  space(publicKey: $publicKey) {
+    dataFields {
+      id,
+      label,
+      type,
+      required,
+      options
+    }
+  }
+}`
+
+// Lists the custom data fields of the space set with WithPublicKey
+func (c *Client) ListDataFieldDefinitions() ([]DataFieldDefinition, error) {
+	var response struct {
+		Space *struct {
+			DataFields []DataFieldDefinition `json:"dataFields"`
+		} `json:"space"`
+	}
+	var variables = map[string]interface{}{"publicKey": c.getPublicKey()}
+
+	var err = c.GraphQL(c.context(), listDataFieldsQuery, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Space == nil {
+		return nil, ErrSpaceNotFound
+	}
+
+	return response.Space.DataFields, nil
+}
+
+// Checks value is acceptable for the field
+func (definition *DataFieldDefinition) Validate(value string) error {
+	if value == "" {
+		if definition.Required {
+			return fmt.Errorf("data field %s is required", definition.Id)
+		}
+		return nil
+	}
+
+	var err error
+	switch definition.Type {
+	case DataFieldNumber:
+		_, err = strconv.ParseFloat(value, 64)
+	case DataFieldCheckbox:
+		_, err = strconv.ParseBool(value)
+	case DataFieldDate:
+		_, err = time.Parse(DataFieldDateLayout, value)
+	case DataFieldSelect:
+		err = errors.New("not one of the options")
+		for _, option := range definition.Options {
+			if option == value {
+				err = nil
+				break
+			}
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("data field %s: invalid %s value %q", definition.Id, definition.Type, value)
+	}
+
+	return nil
+}
+
+// Checks items against the definitions of the space.  Every required field must
+// have a value and every item must belong to a known field.  All problems are
+// returned, joined into one error.
+func ValidateDataItems(definitions []DataFieldDefinition, items []DataItem) error {
+	var values = map[string]string{}
+	var errs []error
+
+	for _, item := range items {
+		values[item.Id] = item.Value
+	}
+
+	var known = map[string]bool{}
+	for i := range definitions {
+		known[definitions[i].Id] = true
+
+		var err = definitions[i].Validate(values[definitions[i].Id])
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, item := range items {
+		if !known[item.Id] {
+			errs = append(errs, fmt.Errorf("unknown data field %s", item.Id))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// The data items of a member
+type DataFields []DataItem
+
+// Returns the item with the given id
+func (fields DataFields) ByID(id string) (DataItem, bool) {
+	for _, item := range fields {
+		if item.Id == id {
+			return item, true
+		}
+	}
+
+	return DataItem{}, false
+}
+
+// Returns the item with the given label
+func (fields DataFields) ByLabel(label string) (DataItem, bool) {
+	for _, item := range fields {
+		if item.Label == label {
+			return item, true
+		}
+	}
+
+	return DataItem{}, false
+}
+
+// Returns the value of a checkbox field.  An empty value is false.
+func (item DataItem) AsBool() (bool, error) {
+	switch strings.ToLower(item.Value) {
+	case "", "off", "no":
+		return false, nil
+	case "on", "yes":
+		return true, nil
+	}
+
+	return strconv.ParseBool(item.Value)
+}
+
+// Returns the value of a number field that holds a whole number
+func (item DataItem) AsInt() (int64, error) {
+	return strconv.ParseInt(strings.TrimSpace(item.Value), 10, 64)
+}
+
+// Returns the value of a date field.  Values in RFC 3339 format are accepted as
+// well.
+func (item DataItem) AsTime() (time.Time, error) {
+	var value = strings.TrimSpace(item.Value)
+
+	t, err := time.Parse(DataFieldDateLayout, value)
+	if err == nil {
+		return t, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+// Returns the values of a field holding several values, either as a JSON array
+// or separated by commas.  An empty value returns no values.
+func (item DataItem) AsStringSlice() []string {
+	var value = strings.TrimSpace(item.Value)
+	if value == "" {
+		return nil
+	}
+
+	var values []string
+	if strings.HasPrefix(value, "[") && json.Unmarshal([]byte(value), &values) == nil {
+		return values
+	}
+
+	values = strings.Split(value, ",")
+	for i := range values {
+		values[i] = strings.TrimSpace(values[i])
+	}
+
+	return values
+}
+
+type dataFieldsRequest struct {
+	DataFields []DataItem `json:"data_fields"`
+	Token      string     `json:"pltoken"`
+}
+
+// Updates the given data fields of the member.  Fields not in items keep their
+// values.
+func (member *Member) UpdateDataFields(items []DataItem) error {
+	var request = dataFieldsRequest{DataFields: items, Token: member.TokenValue()}
+
+	_, err := member.getClient().sendRequest("POST", "/api/services/user?action=data_fields", request)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Sets a single data field of the member
+func (member *Member) SetDataField(id string, value string) error {
+	return member.UpdateDataFields([]DataItem{{Id: id, Value: value}})
+}
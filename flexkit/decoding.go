@@ -0,0 +1,88 @@
+package flexkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Returned in strict mode when a response lacks a field it cannot do without,
+// such as the token of a login or the id of a payment
+var ErrMissingField = errors.New("flexkit: response is missing a required field")
+
+// Makes decoding a response fail when it has fields this package does not
+// know, or lacks fields it needs such as tokens and ids, so changes of the API
+// show up as errors instead of silently missing values.  Fields selected with
+// Fields are still decoded leniently.
+func WithStrictDecoding() Option {
+	return func(client *Client) {
+		client.strict = true
+	}
+}
+
+// Decodes a response body into out, rejecting unknown fields in strict mode
+func (c *Client) decode(data []byte, out interface{}) error {
+	if !c.strict {
+		return json.Unmarshal(data, out)
+	}
+
+	var decoder = json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var err = decoder.Decode(out)
+	if err != nil {
+		return err
+	}
+
+	if response, ok := out.(interface{ missingField() string }); ok {
+		if field := response.missingField(); field != "" {
+			return fmt.Errorf("%w: %s", ErrMissingField, field)
+		}
+	}
+
+	return nil
+}
+
+// The required fields of responses, checked in strict mode.  Each returns the
+// name of a missing field, or an empty string.
+
+func (response tokenResponse) missingField() string {
+	if response.Token == "" {
+		return "token"
+	}
+
+	return ""
+}
+
+func (response loginResponse) missingField() string {
+	if response.Token == "" && response.Challenge == "" {
+		return "token"
+	}
+
+	return ""
+}
+
+func (fields memberFields) missingField() string {
+	if fields.Id == "" {
+		return "id"
+	}
+
+	return ""
+}
+
+func (response paymentIntentResponse) missingField() string {
+	if response.Id == "" {
+		return "id"
+	}
+
+	return ""
+}
+
+// A guest payment answers with an order rather than a payment id
+func (response guestPaymentResponse) missingField() string {
+	if response.OrderId == "" {
+		return "order_id"
+	}
+
+	return ""
+}
@@ -0,0 +1,43 @@
+package flexkit
+
+import "time"
+
+const getDownloadsQuery string = `
+query getDownloads($token: String) {
+  member(token: $token) {
+    downloads {
+      productId,
+      name,
+      url,
+      expiresAt,
+      licenseKey
+    }
+  }
+}`
+
+// A digital product the member bought
+type Download struct {
+	ProductId  string    `json:"productId"`  // Plasso product id
+	Name       string    `json:"name"`       // Name of the file
+	URL        string    `json:"url"`        // Signed link to the file, only valid until ExpiresAt
+	ExpiresAt  time.Time `json:"expiresAt"`  // When URL stops working
+	LicenseKey string    `json:"licenseKey"` // License key of the product, if it has one
+}
+
+// Get the digital products the member bought.  The URLs expire, get the
+// downloads again instead of storing them.
+func (member *Member) GetDownloads() ([]Download, error) {
+	var response struct {
+		Member struct {
+			Downloads []Download `json:"downloads"`
+		} `json:"member"`
+	}
+	var variables = map[string]interface{}{"token": member.TokenValue()}
+
+	var err = member.getClient().GraphQL(member.context(), getDownloadsQuery, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Member.Downloads, nil
+}
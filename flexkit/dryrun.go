@@ -0,0 +1,73 @@
+package flexkit
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+)
+
+// Returned by calls that were not sent because of WithDryRun
+var ErrDryRun = errors.New("flexkit: dry run, request not sent")
+
+// Calls that change nothing and are still sent in dry run mode, keyed by path
+var readOnlyPaths = map[string]bool{
+	"/api/service/login":               true,
+	"/api/service/login/2fa":           true,
+	"/api/service/login/oauth":         true,
+	"/api/service/login_link/exchange": true,
+	"/api/service/jwks":                true,
+	"/api/cart/price":                  true,
+	"/api/shipping/rates":              true,
+	"/api/tax/estimate":                true,
+}
+
+// Stops the client from sending calls that change anything, such as
+// CreatePayment, CreateSubscription, Member.Delete and the SpaceClient
+// mutations.  Those calls validate their request, log it at info level to the
+// logger set with WithLogger or the default slog logger, and return ErrDryRun.
+// Mutations run with GraphQL are skipped the same way.  Queries and calls like
+// Login and EstimateTax are sent as usual.
+func WithDryRun() Option {
+	return func(client *Client) {
+		client.dryRun = true
+	}
+}
+
+// Reports whether a call to path is skipped in dry run mode
+func (c *Client) skipped(path string) bool {
+	var route, _, _ = strings.Cut(path, "?")
+	return c.dryRun && !readOnlyPaths[route]
+}
+
+// Reports whether a GraphQL document is a mutation rather than a query
+func isMutation(query string) bool {
+	return strings.HasPrefix(strings.TrimSpace(query), "mutation")
+}
+
+// Runs the Validate method of request, if it has one
+func validateRequest(request interface{}) error {
+	if validator, ok := request.(interface{ Validate() error }); ok {
+		return validator.Validate()
+	}
+
+	return nil
+}
+
+// Validates and logs a call skipped in dry run mode
+func (c *Client) dryRunRequest(kind string, url string, request interface{}, body []byte) error {
+	var err = validateRequest(request)
+	if err != nil {
+		return err
+	}
+
+	var logger = c.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger.Info("flexkit dry run",
+		"method", kind,
+		"url", url,
+		"body", redact(body))
+
+	return ErrDryRun
+}
@@ -0,0 +1,44 @@
+package flexkit
+
+type emailChangeRequest struct {
+	Email       string `json:"email"`
+	MemberToken string `json:"pltoken"`
+}
+
+type confirmEmailRequest struct {
+	PublicKey string `json:"public_key"`
+	Token     string `json:"token"`
+}
+
+// Asks to change the member's email to newEmail.  Plasso sends a confirmation
+// link to newEmail and the email only changes once ConfirmEmailChange is called
+// with the token of the link, so a stolen session cannot take over the account
+// by swapping its email.
+func (member *Member) RequestEmailChange(newEmail string) error {
+	var err = validateEmail("email change", newEmail, true)
+	if err != nil {
+		return err
+	}
+
+	var request = emailChangeRequest{Email: newEmail, MemberToken: member.TokenValue()}
+
+	_, err = member.getClient().sendRequest("POST", "/api/services/user?action=email_change", request)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Completes an email change requested with Member.RequestEmailChange, using
+// the token from the confirmation link
+func (c *Client) ConfirmEmailChange(token string) error {
+	var request = confirmEmailRequest{PublicKey: c.getPublicKey(), Token: token}
+
+	_, err := c.sendRequest("POST", "/api/service/email/confirm", request)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package flexkit
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Returned when the Plasso API answers with an error status
+type APIError struct {
+	Method     string         // Method of the request
+	URL        string         // Url of the request
+	StatusCode int            // HTTP status of the response
+	Body       []byte         // Body of the response
+	RateLimit  *RateLimitInfo // Rate limit state reported with the response, nil if none was
+	RequestID  string         // X-Request-ID of the request, for tracing it with Plasso support
+}
+
+func (err *APIError) Error() string {
+	return fmt.Sprintf("%s %d %s %s (request id %s)", err.Method, err.StatusCode, err.URL, string(err.Body), err.RequestID)
+}
+
+func newAPIError(req *http.Request, res *http.Response, body []byte) *APIError {
+	return &APIError{
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		StatusCode: res.StatusCode,
+		Body:       body,
+		RateLimit:  parseRateLimit(res.Header),
+		RequestID:  req.Header.Get(requestIDHeader),
+	}
+}
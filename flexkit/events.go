@@ -0,0 +1,75 @@
+package flexkit
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// The kind of an Event
+type EventType string
+
+// Types of events
+const (
+	EventMemberCreated         EventType = "member.created"         // A member signed up
+	EventMemberUpdated         EventType = "member.updated"         // A member changed their settings or data fields
+	EventMemberDeleted         EventType = "member.deleted"         // A member was deleted
+	EventSubscriptionUpdated   EventType = "subscription.updated"   // A member changed plan or quantity
+	EventSubscriptionCancelled EventType = "subscription.cancelled" // A subscription ended
+	EventPaymentSucceeded      EventType = "payment.succeeded"      // A customer was charged
+	EventPaymentFailed         EventType = "payment.failed"         // A charge failed
+	EventPaymentRefunded       EventType = "payment.refunded"       // A payment was refunded
+)
+
+const listEventsQuery string = `
+query listEvents($first: Int, $after: String, $since: String, $types: [String]) {
+  events(first: $first, after: $after, since: $since, types: $types) {
+    nodes {
+      id,
+      type,
+      memberId,
+      createdAt,
+      data
+    },
+    pageInfo {
+      endCursor,
+      hasNextPage
+    }
+  }
+}`
+
+// Something that happened in the space
+type Event struct {
+	Id        string          `json:"id"`        // Plasso event id
+	Type      EventType       `json:"type"`      // What happened
+	MemberId  string          `json:"memberId"`  // The member the event is about, if any
+	CreatedAt time.Time       `json:"createdAt"` // When it happened
+	Data      json.RawMessage `json:"data"`      // Details, depending on Type
+}
+
+// Lists the events of the space since the given time, oldest first.  Only
+// events of the given types are listed, or all events when none are given.
+func (space *SpaceClient) ListEvents(since time.Time, types ...EventType) *Pager[Event] {
+	return newPager(space.client.context(), "", func(ctx context.Context, cursor string) ([]Event, string, bool, error) {
+		var response struct {
+			Events struct {
+				Nodes    []Event  `json:"nodes"`
+				PageInfo pageInfo `json:"pageInfo"`
+			} `json:"events"`
+		}
+		var variables = map[string]interface{}{
+			"first": defaultPageSize,
+			"after": cursor,
+			"since": since.UTC().Format(time.RFC3339Nano),
+			"types": types,
+		}
+
+		var err = space.client.GraphQL(ctx, listEventsQuery, variables, &response)
+		if err != nil {
+			return nil, "", false, err
+		}
+
+		var page = response.Events.PageInfo
+		return response.Events.Nodes, page.EndCursor, page.HasNextPage, nil
+	})
+}
@@ -0,0 +1,110 @@
+package flexkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Selections for member fields that are objects
+var memberFieldSelections = map[string]string{
+	"shippingInfo": "shippingInfo {\n      name\n      address\n      city\n      state\n      zip\n      country\n    }",
+	"dataFields":   "dataFields {\n      id,\n      label,\n      value\n    }",
+	"plan":         "plan {\n      alias\n    }",
+}
+
+// Member fields MemberData has a field for
+var modeledMemberFields = map[string]bool{
+	"id":                true,
+	"name":              true,
+	"email":             true,
+	"ccType":            true,
+	"ccLast4":           true,
+	"shippingInfo":      true,
+	"dataFields":        true,
+	"plan":              true,
+	"trialEnd":          true,
+	"status":            true,
+	"currentPeriodEnd":  true,
+	"cancelAtPeriodEnd": true,
+	"createdAt":         true,
+	"paymentFailedAt":   true,
+	"nextPaymentRetry":  true,
+	"quantity":          true,
+}
+
+var fieldNamePattern = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+type dataOptions struct {
+	fields []string
+}
+
+// An option for GetData
+type DataOption func(options *dataOptions)
+
+// Only fetches the given member fields in GetData.  Names are GraphQL field
+// names such as "id", "email", "shippingInfo", "dataFields" or "plan".  Fields
+// MemberData does not have a field for are returned in MemberData.Extra, so
+// new server side fields can be read without updating this package.
+func Fields(names ...string) DataOption {
+	return func(options *dataOptions) {
+		options.fields = append(options.fields, names...)
+	}
+}
+
+// Builds a getMember query selecting fields
+func memberQuery(fields []string) (string, error) {
+	var selections = make([]string, 0, len(fields))
+	var seen = map[string]bool{}
+
+	for _, field := range fields {
+		if !fieldNamePattern.MatchString(field) {
+			return "", fmt.Errorf("flexkit: invalid field name %q", field)
+		}
+		if seen[field] {
+			continue
+		}
+		seen[field] = true
+
+		if selection, ok := memberFieldSelections[field]; ok {
+			selections = append(selections, selection)
+		} else {
+			selections = append(selections, field)
+		}
+	}
+
+	if len(selections) == 0 {
+		return "", fmt.Errorf("flexkit: no fields selected")
+	}
+
+	return fmt.Sprintf(`
+query getMember($token: String) {
+  member(token: $token) {
+    %s
+  }
+}`, strings.Join(selections, ",\n    ")), nil
+}
+
+// Returns the fields of a getMember response MemberData does not model
+func extraMemberFields(body []byte) (map[string]json.RawMessage, error) {
+	var response struct {
+		Data struct {
+			Member map[string]json.RawMessage `json:"member"`
+		} `json:"data"`
+	}
+
+	var err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	var extra = map[string]json.RawMessage{}
+	for field, value := range response.Data.Member {
+		if !modeledMemberFields[field] {
+			extra[field] = value
+		}
+	}
+
+	return extra, nil
+}
@@ -34,15 +34,18 @@ package flexkit
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io/ioutil"
+	"log/slog"
 	"net/http"
+	"strings"
+	"sync/atomic"
 	"time"
 )
 
 const domain string = "https://plasso.com"
+const sandboxDomain string = "https://sandbox.plasso.com"
 
 const getMemberQuery string = `
 query getMember($token: String) {
@@ -62,41 +65,79 @@ query getMember($token: String) {
     },
     dataFields {
       id,
+      label,
       value
     },
     plan {
     	alias
-    }
+    },
+    trialEnd,
+    status,
+    currentPeriodEnd,
+    cancelAtPeriodEnd,
+    createdAt,
+    paymentFailedAt,
+    nextPaymentRetry,
+    quantity
   }
 }`
 
 type gqlQuery struct {
-	Query     string            `json:"query"`
-	Variables map[string]string `json:"variables"`
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+// An error reported in a GraphQL response
+type GraphQLError struct {
+	Message string        `json:"message"` // Description of the error
+	Path    []interface{} `json:"path"`    // Path of the field that failed, if any
+}
+
+// The errors of a GraphQL response, returned by Client.GraphQL
+type GraphQLErrors []GraphQLError
+
+func (errs GraphQLErrors) Error() string {
+	var messages = make([]string, len(errs))
+	for i, e := range errs {
+		messages[i] = e.Message
+	}
+
+	return "graphql: " + strings.Join(messages, "; ")
+}
+
+type memberFields struct {
+	Id      string `json:"id"`
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	CcType  string `json:"ccType"`
+	CcLast4 string `json:"ccLast4"`
+	Plan    struct {
+		Alias string `json:"alias"`
+	} `json:"plan"`
+	ShippingInfo struct {
+		Name    string `json:"name"`
+		Address string `json:"address"`
+		City    string `json:"city"`
+		State   string `json:"state"`
+		Zip     string `json:"zip"`
+		Country string `json:"country"`
+	} `json:"shippingInfo"`
+	DataFields        []DataItem `json:"dataFields"`
+	TrialEnd          time.Time  `json:"trialEnd"`
+	Status            string     `json:"status"`
+	CurrentPeriodEnd  time.Time  `json:"currentPeriodEnd"`
+	CancelAtPeriodEnd bool       `json:"cancelAtPeriodEnd"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	PaymentFailedAt   time.Time  `json:"paymentFailedAt"`
+	NextPaymentRetry  time.Time  `json:"nextPaymentRetry"`
+	Quantity          int        `json:"quantity"`
 }
 
 type memberDataResponse struct {
 	Data struct {
-		Member struct {
-			Id      string `json:"id"`
-			Name    string `json:"name"`
-			Email   string `json:"email"`
-			CcType  string `json:"ccType"`
-			CcLast4 string `json:"ccLast4"`
-			Plan    struct {
-				Alias string `json:"alias"`
-			} `json:"plan"`
-			ShippingInfo struct {
-				Name    string `json:"name"`
-				Address string `json:"address"`
-				City    string `json:"city"`
-				State   string `json:"state"`
-				Zip     string `json:"zip"`
-				Country string `json:"country"`
-			} `json:"shippingInfo"`
-			DataFields []DataItem `json:"dataFields"`
-		} `json:"member"`
+		Member json.RawMessage `json:"member"`
 	} `json:"data"`
+	Errors GraphQLErrors `json:"errors"`
 }
 
 // The structure that should be filled out and passed to the Login function.
@@ -108,9 +149,9 @@ type LoginRequest struct {
 
 // This structure represents a product.
 type Product struct {
-	Id     string `json:"id"`     // Plasso product id
-	Qty    string `json:"qty"`    // Quantity
-	Amount string `json:"amount"` // Amount for variable price products
+	Id     string `json:"id"`  // Plasso product id
+	Qty    string `json:"qty"` // Quantity
+	Amount Money  `json:"-"`   // Amount for variable price products
 }
 
 // The structure that should be filled out and passed to the CreatePayment function.
@@ -118,109 +159,478 @@ type PaymentRequest struct {
 	PublicKey       string     `json:"public_key"`       // Plasso customer public key
 	Token           string     `json:"token"`            // Token returned from javascript flexkit GetToken call
 	Products        []Product  `json:"products"`         // List of products
-	BillingAddress  string     `json:"billing_address"`  // Billing address of customer (optional depending on plan).
-	BillingCity     string     `json:"billing_city"`     // Billing city of customer (optional depending on plan).
-	BillingState    string     `json:"billing_state"`    // Billing state of customer (optional depending on plan).
-	BillingZip      string     `json:"billing_zip"`      // Billing zip of customer (optional depending on plan).
-	BillingCountry  string     `json:"billing_country"`  // Billing country of customer (optional depending on plan).
-	ShippingName    string     `json:"shipping_name"`    // Shipping name of customer (optional depending on plan).
-	ShippingAddress string     `json:"shipping_address"` // Shipping address of customer (optional depending on plan).
-	ShippingCity    string     `json:"shipping_city"`    // Shipping city of customer (optional depending on plan).
-	ShippingState   string     `json:"shipping_state"`   // Shipping state of customer (optional depending on plan).
-	ShippingZip     string     `json:"shipping_zip"`     // Shipping zip of customer (optional depending on plan).
-	ShippingCountry string     `json:"shipping_country"` // Shipping country of customer (optional depending on plan).
+	Billing         Address    `json:"-"`                // Billing address of customer (optional depending on plan).
+	Shipping        Address    `json:"-"`                // Shipping address of customer (optional depending on plan).
 	ShippingOptions string     `json:"shipping_options"` // Shipping options of customer (optional depending on plan).
 	DataFields      []DataItem `json:"data_fields"`      // Data items (optional)
 	Coupon          string     `json:"coupon"`           // Coupon code (optional)
 	Email           string     `json:"email"`            // Email customer provided
 	Name            string     `json:"name"`             // Name of customer
+	TaxID           string     `json:"tax_id"`           // Tax id of a business customer, e.g. a VAT number (optional)
+	TaxIDType       string     `json:"tax_id_type"`      // One of the TaxID* types (optional)
+	Referrer        string     `json:"referrer"`         // Referral code of the member who referred the customer (optional)
+	IdempotencyKey  string     `json:"-"`                // Retries with the same key are only charged once (optional)
 }
 
 // Represents a data item
 type DataItem struct {
-	Id    string `json:"id"`    // The id of the data item
-	Value string `json:"value"` // The value of the data item
+	Id    string `json:"id"`              // The id of the data item
+	Value string `json:"value"`           // The value of the data item
+	Label string `json:"label,omitempty"` // Informational, label of the data field
 }
 
 // The structure that should be filled out and passed to the CreateSubscription function.
 type SubscriptionRequest struct {
 	SubscriptionFor string     `json:"subscription_for"`
-	Email           string     `json:"email"`            // Email customer provided
-	Name            string     `json:"name"`             // Name of customer
-	Password        string     `json:"password"`         // Customer Password
-	Plan            string     `json:"plan"`             // The plan id you are subscribing to
-	Token           string     `json:"token"`            // Token returned from javascript flexkit GetToken call
-	BillingAddress  string     `json:"billing_address"`  // Billing address of customer (optional depending on plan).
-	BillingCity     string     `json:"billing_city"`     // Billing city of customer (optional depending on plan).
-	BillingState    string     `json:"billing_state"`    // Billing state of customer (optional depending on plan).
-	BillingZip      string     `json:"billing_zip"`      // Billing zip of customer (optional depending on plan).
-	BillingCountry  string     `json:"billing_country"`  // Billing country of customer (optional depending on plan).
-	ShippingName    string     `json:"shipping_name"`    // Shipping name of customer (optional depending on plan).
-	ShippingAddress string     `json:"shipping_address"` // Shipping address of customer (optional depending on plan).
-	ShippingCity    string     `json:"shipping_city"`    // Shipping city of customer (optional depending on plan).
-	ShippingState   string     `json:"shipping_state"`   // Shipping state of customer (optional depending on plan).
-	ShippingZip     string     `json:"shipping_zip"`     // Shipping zip of customer (optional depending on plan).
-	ShippingCountry string     `json:"shipping_country"` // Shipping country of customer (optional depending on plan).
-	ShippingOptions string     `json:"shipping_options"` // Shipping options of customer (optional depending on plan).
-	DataFields      []DataItem `json:"data_fields"`      // Data items (optional)
-	PublicKey       string     `json:"public_key"`       // Plasso customer public key
+	Email           string     `json:"email"`                // Email customer provided
+	Name            string     `json:"name"`                 // Name of customer
+	Password        string     `json:"password"`             // Customer Password
+	Plan            string     `json:"plan"`                 // The plan id you are subscribing to
+	Token           string     `json:"token"`                // Token returned from javascript flexkit GetToken call
+	Billing         Address    `json:"-"`                    // Billing address of customer (optional depending on plan).
+	Shipping        Address    `json:"-"`                    // Shipping address of customer (optional depending on plan).
+	ShippingOptions string     `json:"shipping_options"`     // Shipping options of customer (optional depending on plan).
+	DataFields      []DataItem `json:"data_fields"`          // Data items (optional)
+	PublicKey       string     `json:"public_key"`           // Plasso customer public key
+	TrialDays       int        `json:"trial_days,omitempty"` // Overrides the trial length of the plan (optional)
+	TaxID           string     `json:"tax_id"`               // Tax id of a business customer, e.g. a VAT number (optional)
+	TaxIDType       string     `json:"tax_id_type"`          // One of the TaxID* types (optional)
+	Referrer        string     `json:"referrer"`             // Referral code of the member who referred the customer (optional)
+	IdempotencyKey  string     `json:"-"`                    // Retries with the same key only subscribe once (optional)
+}
+
+// The structure that should be filled out and passed to the
+// CreateFreeSubscription function.
+type FreeSubscriptionRequest struct {
+	Email          string     `json:"email"`       // Email customer provided
+	Name           string     `json:"name"`        // Name of customer
+	Password       string     `json:"password"`    // Customer Password
+	Plan           string     `json:"plan"`        // The id of the free plan
+	DataFields     []DataItem `json:"data_fields"` // Data items (optional)
+	PublicKey      string     `json:"public_key"`  // Plasso customer public key
+	Referrer       string     `json:"referrer"`    // Referral code of the member who referred the customer (optional)
+	IdempotencyKey string     `json:"-"`           // Retries with the same key only subscribe once (optional)
+}
+
+type freeSubscriptionRequest struct {
+	SubscriptionFor string `json:"subscription_for"`
+	FreeSubscriptionRequest
 }
 
 type tokenResponse struct {
 	Token string `json:"token"`
 }
 
+// A request of a member action, sent with the member token as pltoken next to
+// the fields of the wrapped request
+type memberRequest struct {
+	request interface{}
+	token   string
+}
+
+func (wire memberRequest) MarshalJSON() ([]byte, error) {
+	return marshalWithField(wire.request, "pltoken", wire.token)
+}
+
+func (wire memberRequest) Validate() error {
+	return validateRequest(wire.request)
+}
+
+// Marshals request, which must marshal to a JSON object, with an extra field
+func marshalWithField(request interface{}, key string, value interface{}) ([]byte, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	err = json.Unmarshal(body, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	fields[key], err = json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(fields)
+}
+
 // A request to update a members payment information
 type CreditCardRequest struct {
-	Last4       string `json:"cc_last_4"` // Informational, Last 4 of credit card
-	Type        string `json:"cc_type"`   // Informational, type of card
-	PlanId      string `json:"plan"`      // Allows changing plan
-	Token       string `json:"token"`     // Stripe source token
-	memberToken string `json:"pltoken"`
+	Last4  string `json:"cc_last_4"` // Informational, Last 4 of credit card
+	Type   string `json:"cc_type"`   // Informational, type of card
+	PlanId string `json:"plan"`      // Allows changing plan
+	Token  string `json:"token"`     // Stripe source token
 }
 
 // A request to change a members settings
 type SettingsRequest struct {
-	Email           string `json:"email"`            // Email customer provided
-	Name            string `json:"name"`             // Name of customer
-	ShippingName    string `json:"shipping_name"`    // Shipping name of customer (optional depending on plan).
-	ShippingAddress string `json:"shipping_address"` // Shipping address of customer (optional depending on plan).
-	ShippingCity    string `json:"shipping_city"`    // Shipping city of customer (optional depending on plan).
-	ShippingState   string `json:"shipping_state"`   // Shipping state of customer (optional depending on plan).
-	ShippingZip     string `json:"shipping_zip"`     // Shipping zip of customer (optional depending on plan).
-	ShippingCountry string `json:"shipping_country"` // Shipping country of customer (optional depending on plan).
-	ShippingOptions string `json:"shipping_options"` // Shipping options of customer (optional depending on plan).
-	token           string `json:"pltoken"`
-}
-
-// A handle to a member
+	// Email customer provided
+	//
+	// Deprecated: changes the email without confirmation, use
+	// Member.RequestEmailChange instead.
+	Email           string  `json:"email"`
+	Name            string  `json:"name"`             // Name of customer
+	Shipping        Address `json:"-"`                // Shipping address of customer (optional depending on plan).
+	ShippingOptions string  `json:"shipping_options"` // Shipping options of customer (optional depending on plan).
+}
+
+// A handle to a member.  A Member is safe for concurrent use, so it can be
+// shared by the handlers of a web server.
 type Member struct {
 	PublicKey string // Public key of Plasso user
-	Token     string // This token changes after every login
+	token     atomic.Pointer[string]
+	client    *Client
 }
 
 // Information about a member
 type MemberData struct {
-	Id              string     // A unique id identifying the user, does not change
-	Email           string     // Email customer provided
-	Name            string     // Name of customer
-	CreditCardLast4 string     // Informational, Last 4 of credit card
-	CreditCardType  string     // Informational, type of card
-	ShippingName    string     // Shipping name of customer (optional depending on plan).
-	ShippingAddress string     // Shipping address of customer (optional depending on plan).
-	ShippingCity    string     // Shipping city of customer (optional depending on plan).
-	ShippingState   string     // Shipping state of customer (optional depending on plan).
-	ShippingZip     string     // Shipping zip of customer (optional depending on plan).
-	ShippingCountry string     // Shipping country of customer (optional depending on plan).
-	ShippingOptions string     // Shipping options of customer (optional depending on plan).
-	DataFields      []DataItem // Data items (optional)
-	Plan            string     // Plan ID
-}
-
-func graphQL(query string, variables map[string]string, response interface{}) error {
-	var client = &http.Client{
-		Timeout: 15 * time.Second,
+	Id                string     // A unique id identifying the user, does not change
+	Email             string     // Email customer provided
+	Name              string     // Name of customer
+	CreditCardLast4   string     // Informational, Last 4 of credit card
+	CreditCardType    string     // Informational, type of card
+	ShippingName      string     // Shipping name of customer (optional depending on plan).
+	ShippingAddress   string     // Shipping address of customer (optional depending on plan).
+	ShippingCity      string     // Shipping city of customer (optional depending on plan).
+	ShippingState     string     // Shipping state of customer (optional depending on plan).
+	ShippingZip       string     // Shipping zip of customer (optional depending on plan).
+	ShippingCountry   string     // Shipping country of customer (optional depending on plan).
+	ShippingOptions   string     // Shipping options of customer (optional depending on plan).
+	DataFields        DataFields // Data items (optional)
+	Plan              string     // Plan ID
+	TrialEnd          time.Time  // When the trial ends, zero if the member never had a trial
+	Status            string     // One of the Subscription* statuses
+	CurrentPeriodEnd  time.Time  // When the current billing period ends and the subscription renews
+	CancelAtPeriodEnd bool       // Whether the subscription ends at CurrentPeriodEnd instead of renewing
+	CreatedAt         time.Time  // When the member signed up
+	PaymentFailedAt   time.Time  // When the latest payment failed, zero unless Status is SubscriptionPastDue
+	NextPaymentRetry  time.Time  // When the failed payment is retried automatically, zero if it is not
+	Quantity          int        // Number of seats the member pays for
+
+	Extra map[string]json.RawMessage // Fields selected with Fields that MemberData does not model
+}
+
+// A client for a flexkit space.  Use NewClient to create one, the package level
+// functions use a client talking to plasso.com.
+type Client struct {
+	baseURL          string
+	publicKey        string
+	environment      Environment
+	requestHooks     []func(*http.Request)
+	responseHooks    []func(*http.Response, []byte, error)
+	logger           *slog.Logger
+	header           http.Header         // Sent with every request
+	idempotency      bool                // Generate missing idempotency keys
+	timeout          time.Duration       // Timeout of REST calls
+	graphQLTimeout   time.Duration       // Timeout of GraphQL calls
+	ctx              context.Context     // Set by WithContext
+	rateLimitRetries int                 // Retries of calls that got a 429
+	breaker          *circuitBreaker     // Set by WithCircuitBreaker
+	maxResponseSize  int64               // Largest response body read, 0 for no limit
+	transport        *http.Transport     // Tuned by the transport options
+	httpClient       *http.Client        // Shared by all calls
+	appInfo          string              // Added to the User-Agent, set by WithAppInfo
+	strict           bool                // Reject unknown fields in responses
+	roundTripper     http.RoundTripper   // Replaces the transport, set by WithRoundTripper
+	dryRun           bool                // Skip calls that change anything
+	credentials      CredentialsProvider // Set by WithCredentials
+	spaceAuth        bool                // Authenticate with the secret key, set for SpaceClient
+	secretKey        string              // Secret key given to NewSpaceClient
+	signing          bool                // Sign requests instead of sending the secret key
+	loginThrottle    *loginThrottle      // Set by WithLoginThrottle
+}
+
+// Configures a Client created by NewClient
+type Option func(client *Client)
+
+// The Plasso environment a client talks to
+type Environment int
+
+const (
+	Production Environment = iota // Live payments on plasso.com
+	Sandbox                       // Plasso's test environment, no real charges are made
+)
+
+func (environment Environment) String() string {
+	switch environment {
+	case Production:
+		return "production"
+	case Sandbox:
+		return "sandbox"
+	}
+
+	return fmt.Sprintf("Environment(%d)", int(environment))
+}
+
+// The calls that can be made without a member, implemented by Client.  Accept
+// this interface instead of *Client to be able to substitute a mock in tests.
+type API interface {
+	Login(request LoginRequest) (*Member, error)
+	SendLoginLink(email string) error
+	ExchangeLoginLinkToken(token string) (*Member, error)
+	CompleteLogin(challenge string, code string) (*Member, error)
+	LoginWithOAuth(provider OAuthProvider, providerToken string) (*Member, error)
+	NewMember(publicKey string, token string) *Member
+	CreatePayment(request PaymentRequest) error
+	CreateGuestPayment(request PaymentRequest) (*GuestOrder, error)
+	ClaimGuestOrder(claimToken string, password string) (*Member, error)
+	ConfirmEmailChange(token string) error
+	AuthorizePayment(request PaymentRequest) (*PaymentIntent, error)
+	ConfirmPayment(intentID string) (*PaymentIntent, error)
+	GetInvoice(id string) (*Invoice, error)
+	GetReceipt(paymentID string) (*Receipt, error)
+	ResendReceipt(paymentID string, email string) error
+	EstimateTax(request TaxRequest) (*TaxEstimate, error)
+	CreateSubscription(request SubscriptionRequest) (*Member, error)
+	CreateFreeSubscription(request FreeSubscriptionRequest) (*Member, error)
+	CreateGiftSubscription(request GiftRequest) (*Gift, error)
+	RedeemGift(code string, request SubscriptionRequest) (*Member, error)
+	CreateCheckoutSession(request CheckoutRequest) (*CheckoutSession, error)
+	CreatePaymentLink(products []Product, options PaymentLinkOptions) (*PaymentLink, error)
+	GetShippingOptions(address Address, products []Product) ([]ShippingOption, error)
+	CheckStock(productIDs []string) ([]StockLevel, error)
+}
+
+// The calls that can be made on a member, implemented by Member.
+type MemberAPI interface {
+	TokenValue() string
+	GetData(options ...DataOption) (*MemberData, error)
+	UpdateSettings(request SettingsRequest) error
+	RequestEmailChange(newEmail string) error
+	Enable2FA() (string, error)
+	Confirm2FA(code string) error
+	UpdateCreditCard(request CreditCardRequest) error
+	UpdateDataFields(items []DataItem) error
+	SetDataField(id string, value string) error
+	ListPaymentMethods() ([]PaymentMethod, error)
+	AddPaymentMethod(token string) (*PaymentMethod, error)
+	SetDefaultPaymentMethod(id string) error
+	DeletePaymentMethod(id string) error
+	ListInvoices() *Pager[Invoice]
+	UpcomingInvoice() (*UpcomingInvoice, error)
+	TrialStatus() (*TrialStatus, error)
+	PauseSubscription(until *time.Time) error
+	ResumeSubscription() error
+	RetryLatestPayment() (*PaymentIntent, error)
+	UpdateSubscriptionQuantity(quantity int, options ...QuantityOption) error
+	ReportUsage(id string, metric string, quantity int64, at time.Time) (string, error)
+	CreateTeam(name string) (*Team, error)
+	GetTeam() (*Team, error)
+	GetReferralCode() (*Referral, error)
+	ListOrders() *Pager[Order]
+	GetDownloads() ([]Download, error)
+	Watch(ctx context.Context, options ...WatchOption) <-chan MemberChange
+	ListSessions() ([]Session, error)
+	LogoutAllSessions() error
+	Delete() error
+	Logout() error
+}
+
+var _ API = (*Client)(nil)
+var _ MemberAPI = (*Member)(nil)
+
+var defaultClient = NewClient()
+
+// Sets the url requests are sent to, defaults to https://plasso.com
+func WithBaseURL(baseURL string) Option {
+	return func(client *Client) {
+		client.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// Sets the public key of the space, used by calls that are not made for a
+// particular member such as GetSpace and by requests that leave PublicKey
+// empty.  A key from WithCredentials takes precedence.
+func WithPublicKey(publicKey string) Option {
+	return func(client *Client) {
+		client.publicKey = publicKey
+	}
+}
+
+// Sends requests to the given environment, defaults to Production.  Requests
+// are tagged with an X-Plasso-Environment header.  Use WithBaseURL after this
+// option to override the url of the environment.
+func WithEnvironment(environment Environment) Option {
+	return func(client *Client) {
+		client.environment = environment
+		if environment == Sandbox {
+			client.baseURL = sandboxDomain
+		} else {
+			client.baseURL = domain
+		}
+	}
+}
+
+// Calls hook with every request before it is sent.  The request body can be
+// read with req.GetBody.
+func WithRequestHook(hook func(req *http.Request)) Option {
+	return func(client *Client) {
+		client.requestHooks = append(client.requestHooks, hook)
+	}
+}
+
+// Calls hook with every response and its body once it has been read.  When the
+// request failed res is nil and err is set.
+func WithResponseHook(hook func(res *http.Response, body []byte, err error)) Option {
+	return func(client *Client) {
+		client.responseHooks = append(client.responseHooks, hook)
+	}
+}
+
+// Creates a new client
+func NewClient(options ...Option) *Client {
+	var client = &Client{
+		baseURL:         domain,
+		header:          http.Header{},
+		timeout:         defaultTimeout,
+		graphQLTimeout:  defaultGraphQLTimeout,
+		maxResponseSize: defaultMaxResponseSize,
+		transport:       newTransport(),
+	}
+	for _, option := range options {
+		option(client)
+	}
+	client.httpClient = &http.Client{Transport: client.transport}
+	if client.roundTripper != nil {
+		client.httpClient.Transport = client.roundTripper
+	}
+
+	return client
+}
+
+// Creates a handle to a member from a token returned by TokenValue, e.g. one
+// stored in a session
+func NewMember(publicKey string, token string) *Member {
+	return defaultClient.NewMember(publicKey, token)
+}
+
+// Creates a handle to a member from a token returned by TokenValue, e.g. one
+// stored in a session
+func (c *Client) NewMember(publicKey string, token string) *Member {
+	var member = &Member{PublicKey: publicKey, client: c}
+	member.setToken(token)
+	return member
+}
+
+// Returns the token of the member.  The token changes after every login.
+func (member *Member) TokenValue() string {
+	var token = member.token.Load()
+	if token == nil {
+		return ""
+	}
+
+	return *token
+}
+
+func (member *Member) setToken(token string) {
+	member.token.Store(&token)
+}
+
+func (member *Member) getClient() *Client {
+	if member.client == nil {
+		return defaultClient
+	}
+
+	return member.client
+}
+
+// Creates a JSON request with the headers every call carries
+func (c *Client) newRequest(ctx context.Context, kind string, url string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, kind, url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", c.userAgent())
+	req.Header.Set("X-Plasso-Environment", c.environment.String())
+	for key, values := range c.header {
+		req.Header[key] = values
+	}
+	for key, values := range contextHeader(ctx) {
+		req.Header[key] = values
+	}
+	setRequestID(req)
+
+	err = c.authenticate(req, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// Sends req and reads the response body, retrying rate limited requests if
+// enabled with WithRateLimitRetries
+func (c *Client) do(req *http.Request) (*http.Response, []byte, error) {
+	for attempt := 0; ; attempt++ {
+		if c.breaker != nil && !c.breaker.allow() {
+			return nil, nil, ErrCircuitOpen
+		}
+
+		res, body, err := c.roundTrip(req)
+		if c.breaker != nil {
+			c.breaker.record(canceledByCaller(req.Context()), err != nil || res.StatusCode >= 500)
+		}
+		if err != nil || res.StatusCode != http.StatusTooManyRequests || attempt >= c.rateLimitRetries {
+			return res, body, err
+		}
+
+		var delay = rateLimitWait(res.Header, attempt)
+		if delay > maxRateLimitWait || req.GetBody == nil {
+			return res, body, nil
+		}
+
+		c.logRetry(req, res, attempt+1, delay)
+		if !sleep(req.Context(), delay) {
+			return res, body, nil
+		}
+
+		req = req.Clone(req.Context())
+		req.Body, err = req.GetBody()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+}
+
+// Sends req once and reads the response body, running the hooks
+func (c *Client) roundTrip(req *http.Request) (*http.Response, []byte, error) {
+	for _, hook := range c.requestHooks {
+		hook(req)
 	}
+	c.logRequest(req)
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		c.runResponseHooks(nil, nil, err)
+		c.logResponse(req, nil, nil, err)
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	responseBody, err := c.readBody(res.Body)
+	recordResponse(req, res, responseBody)
+	c.runResponseHooks(res, responseBody, err)
+	c.logResponse(req, res, responseBody, err)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res, responseBody, nil
+}
+
+func (c *Client) runResponseHooks(res *http.Response, body []byte, err error) {
+	for _, hook := range c.responseHooks {
+		hook(res, body, err)
+	}
+}
+
+func (c *Client) graphQL(ctx context.Context, query string, variables map[string]interface{}, response interface{}) error {
+	ctx, cancel := withTimeout(ctx, c.graphQLTimeout)
+	defer cancel()
 
 	var gql = gqlQuery{query, variables}
 
@@ -229,63 +639,88 @@ func graphQL(query string, variables map[string]string, response interface{}) er
 		return err
 	}
 
-	var url = fmt.Sprintf("%s/graphql", domain)
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(body))
-	if err != nil {
-		return err
+	var url = fmt.Sprintf("%s/graphql", c.baseURL)
+	if c.dryRun && isMutation(query) {
+		return c.dryRunRequest("POST", url, nil, body)
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	res, err := client.Do(req)
+	req, err := c.newRequest(ctx, "POST", url, body)
 	if err != nil {
 		return err
 	}
-	defer res.Body.Close()
 
-	responseBody, err := ioutil.ReadAll(res.Body)
+	res, responseBody, err := c.do(req)
 	if err != nil {
 		return err
 	}
 
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return newAPIError(req, res, responseBody)
+	}
+	c.logGraphQLErrors(req, responseBody)
+
 	return json.Unmarshal(responseBody, response)
 }
 
-func sendRequest(kind string, path string, request interface{}) ([]byte, error) {
-	var url = fmt.Sprintf("%s%s", domain, path)
-	var client = &http.Client{
-		Timeout: 30 * time.Second,
+// Runs a GraphQL query against the Plasso API, for fields this package does not
+// model yet.  The data of the response is decoded into out, errors in the
+// response are returned as GraphQLErrors.
+func (c *Client) GraphQL(ctx context.Context, query string, variables map[string]interface{}, out interface{}) error {
+	var response struct {
+		Data   json.RawMessage `json:"data"`
+		Errors GraphQLErrors   `json:"errors"`
 	}
 
-	body, err := json.Marshal(request)
+	var err = c.graphQL(ctx, query, variables, &response)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	if len(response.Errors) > 0 {
+		return response.Errors
 	}
 
-	req, err := http.NewRequest(kind, url, bytes.NewBuffer(body))
+	if out == nil || len(response.Data) == 0 {
+		return nil
+	}
+
+	return c.decode(response.Data, out)
+}
+
+func (c *Client) sendRequest(kind string, path string, request interface{}) ([]byte, error) {
+	return c.send(c.context(), kind, path, nil, request)
+}
+
+// Sends a REST request with header added to the headers every call carries
+func (c *Client) send(ctx context.Context, kind string, path string, header http.Header, request interface{}) ([]byte, error) {
+	var url = fmt.Sprintf("%s%s", c.baseURL, path)
+	ctx, cancel := withTimeout(ctx, c.timeout)
+	defer cancel()
+
+	body, err := json.Marshal(request)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	res, err := client.Do(req)
+	if c.skipped(path) {
+		return nil, c.dryRunRequest(kind, url, request, body)
+	}
+
+	req, err := c.newRequest(ctx, kind, url, body)
 	if err != nil {
 		return nil, err
 	}
-	defer res.Body.Close()
+	for key, values := range header {
+		req.Header[key] = values
+	}
 
-	responseBody, err := ioutil.ReadAll(res.Body)
+	res, responseBody, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
 
 	if res.StatusCode < 200 || res.StatusCode > 299 {
-		var errorText = fmt.Sprintf(
-			"%s %d %s %s",
-			kind,
-			res.StatusCode,
-			url,
-			string(responseBody))
-		return responseBody, errors.New(errorText)
+		return responseBody, newAPIError(req, res, responseBody)
 	}
 
 	return responseBody, nil
@@ -293,52 +728,134 @@ func sendRequest(kind string, path string, request interface{}) ([]byte, error)
 
 // Authenticates and returns a Member.
 func Login(request LoginRequest) (*Member, error) {
-	body, err := sendRequest("POST", "/api/service/login", request)
+	return defaultClient.Login(request)
+}
+
+// Authenticates and returns a Member.  Returns a TwoFactorRequiredError for
+// members with two-factor authentication enabled, and an AccountLockedError
+// after too many failed attempts.
+func (c *Client) Login(request LoginRequest) (*Member, error) {
+	request.PublicKey = c.requestPublicKey(request.PublicKey)
+	var err = c.waitForLogin(request.Email)
 	if err != nil {
 		return nil, err
 	}
 
-	var r tokenResponse
-	err = json.Unmarshal(body, &r)
+	body, err := c.sendRequest("POST", "/api/service/login", request)
 	if err != nil {
+		err = lockoutError(body, err)
+		c.recordLogin(request.Email, err)
 		return nil, err
 	}
 
-	return &Member{request.PublicKey, r.Token}, nil
+	var r loginResponse
+	err = c.decode(body, &r)
+	if err != nil {
+		return nil, err
+	}
+	if r.Challenge != "" {
+		return nil, &TwoFactorRequiredError{Challenge: r.Challenge}
+	}
+
+	return c.NewMember(request.PublicKey, r.Token), nil
+}
+
+// Get member details.  Pass Fields to only fetch some of them.  When Plasso
+// returns no member, e.g. because the token expired, its errors are returned
+// as GraphQLErrors.
+func (member *Member) GetData(options ...DataOption) (*MemberData, error) {
+	return member.getData(member.context(), options...)
 }
 
-// Get member details
-func (member *Member) GetData() (*MemberData, error) {
+func (member *Member) getData(ctx context.Context, options ...DataOption) (*MemberData, error) {
 	var response memberDataResponse
-	var variables = map[string]string{"token": member.Token}
+	var fields memberFields
+	var client = member.getClient()
+	var variables = map[string]interface{}{"token": member.TokenValue()}
 	var memberData MemberData
+	var dataOptions dataOptions
+	var query = getMemberQuery
+	var err error
+
+	for _, option := range options {
+		option(&dataOptions)
+	}
+
+	if dataOptions.fields != nil {
+		query, err = memberQuery(dataOptions.fields)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var body json.RawMessage
+	err = client.graphQL(ctx, query, variables, &body)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, err
+	}
 
-	var err = graphQL(getMemberQuery, variables, &response)
+	// Plasso answers with errors and a null member when the token is no longer
+	// valid
+	if len(response.Errors) > 0 && (len(response.Data.Member) == 0 || string(response.Data.Member) == "null") {
+		return nil, response.Errors
+	}
+
+	// Fields selects fields MemberData does not model, which strict decoding
+	// would reject
+	if dataOptions.fields != nil {
+		err = json.Unmarshal(response.Data.Member, &fields)
+	} else if len(response.Data.Member) > 0 {
+		err = client.decode(response.Data.Member, &fields)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	memberData.CreditCardLast4 = response.Data.Member.CcLast4
-	memberData.CreditCardType = response.Data.Member.CcType
-	memberData.DataFields = response.Data.Member.DataFields
-	memberData.Email = response.Data.Member.Email
-	memberData.Id = response.Data.Member.Id
-	memberData.Name = response.Data.Member.Name
-	memberData.Plan = response.Data.Member.Plan.Alias
-	memberData.ShippingAddress = response.Data.Member.ShippingInfo.Address
-	memberData.ShippingCity = response.Data.Member.ShippingInfo.City
-	memberData.ShippingCountry = response.Data.Member.ShippingInfo.Country
-	memberData.ShippingName = response.Data.Member.ShippingInfo.Name
-	memberData.ShippingState = response.Data.Member.ShippingInfo.State
-	memberData.ShippingZip = response.Data.Member.ShippingInfo.Zip
+	if dataOptions.fields != nil {
+		memberData.Extra, err = extraMemberFields(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fields.copyTo(&memberData)
 
 	return &memberData, nil
 }
 
+func (fields *memberFields) copyTo(memberData *MemberData) {
+	memberData.CreditCardLast4 = fields.CcLast4
+	memberData.CreditCardType = fields.CcType
+	memberData.DataFields = fields.DataFields
+	memberData.Email = fields.Email
+	memberData.Id = fields.Id
+	memberData.Name = fields.Name
+	memberData.Plan = fields.Plan.Alias
+	memberData.ShippingAddress = fields.ShippingInfo.Address
+	memberData.ShippingCity = fields.ShippingInfo.City
+	memberData.ShippingCountry = fields.ShippingInfo.Country
+	memberData.ShippingName = fields.ShippingInfo.Name
+	memberData.ShippingState = fields.ShippingInfo.State
+	memberData.ShippingZip = fields.ShippingInfo.Zip
+	memberData.TrialEnd = fields.TrialEnd
+	memberData.Status = fields.Status
+	memberData.CurrentPeriodEnd = fields.CurrentPeriodEnd
+	memberData.CancelAtPeriodEnd = fields.CancelAtPeriodEnd
+	memberData.CreatedAt = fields.CreatedAt
+	memberData.PaymentFailedAt = fields.PaymentFailedAt
+	memberData.NextPaymentRetry = fields.NextPaymentRetry
+	memberData.Quantity = fields.Quantity
+}
+
 // Update member settings
 func (member *Member) UpdateSettings(request SettingsRequest) error {
-	request.token = member.Token
-	_, err := sendRequest("POST", "/api/services/user?action=settings", request)
+	var wire = memberRequest{request, member.TokenValue()}
+	_, err := member.getClient().sendRequest("POST", "/api/services/user?action=settings", wire)
 	if err != nil {
 		return err
 	}
@@ -348,8 +865,8 @@ func (member *Member) UpdateSettings(request SettingsRequest) error {
 
 // Update members payment details
 func (member *Member) UpdateCreditCard(request CreditCardRequest) error {
-	request.memberToken = member.Token
-	_, err := sendRequest("POST", "/api/services/user?action=cc", request)
+	var wire = memberRequest{request, member.TokenValue()}
+	_, err := member.getClient().sendRequest("POST", "/api/services/user?action=cc", wire)
 	if err != nil {
 		return err
 	}
@@ -359,36 +876,87 @@ func (member *Member) UpdateCreditCard(request CreditCardRequest) error {
 
 // Creates a new payment
 func CreatePayment(request PaymentRequest) error {
-	_, err := sendRequest("POST", "/api/payments", request)
+	return defaultClient.CreatePayment(request)
+}
+
+// Creates a new payment.  If the customer's bank asks for authentication an
+// *ActionRequiredError is returned, see PaymentIntent.Confirm.
+func (c *Client) CreatePayment(request PaymentRequest) error {
+	request.PublicKey = c.requestPublicKey(request.PublicKey)
+	body, err := c.send(c.context(), "POST", "/api/payments", c.idempotencyHeader(request.IdempotencyKey), request)
+	if err != nil {
+		return stockError(body, err)
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	var intent = &PaymentIntent{publicKey: request.PublicKey, client: c}
+	err = intent.update(body)
 	if err != nil {
 		return err
 	}
 
+	if intent.Status == PaymentRequiresAction {
+		return &ActionRequiredError{intent}
+	}
+
 	return nil
 }
 
 // Creates a new subscription to a plan
 func CreateSubscription(request SubscriptionRequest) (*Member, error) {
+	return defaultClient.CreateSubscription(request)
+}
+
+// Creates a new subscription to a plan
+func (c *Client) CreateSubscription(request SubscriptionRequest) (*Member, error) {
 	request.SubscriptionFor = "space"
-	body, err := sendRequest("POST", "/api/subscriptions", request)
+	request.PublicKey = c.requestPublicKey(request.PublicKey)
+	body, err := c.send(c.context(), "POST", "/api/subscriptions", c.idempotencyHeader(request.IdempotencyKey), request)
+	if err != nil {
+		return nil, err
+	}
+
+	var r tokenResponse
+	err = c.decode(body, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.NewMember(request.PublicKey, r.Token), nil
+}
+
+// Creates a new subscription to a free plan, no payment token is needed
+func CreateFreeSubscription(request FreeSubscriptionRequest) (*Member, error) {
+	return defaultClient.CreateFreeSubscription(request)
+}
+
+// Creates a new subscription to a free plan, no payment token is needed.  The
+// signup fails if the plan is not free.
+func (c *Client) CreateFreeSubscription(request FreeSubscriptionRequest) (*Member, error) {
+	request.PublicKey = c.requestPublicKey(request.PublicKey)
+	var wire = freeSubscriptionRequest{SubscriptionFor: "space", FreeSubscriptionRequest: request}
+	body, err := c.send(c.context(), "POST", "/api/subscriptions?action=free", c.idempotencyHeader(request.IdempotencyKey), wire)
 	if err != nil {
 		return nil, err
 	}
 
 	var r tokenResponse
-	err = json.Unmarshal(body, &r)
+	err = c.decode(body, &r)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Member{request.PublicKey, r.Token}, nil
+	return c.NewMember(request.PublicKey, r.Token), nil
 }
 
 // Deletes the member.  The member object cannot be used after this call and must be recreated.
 func (member *Member) Delete() error {
-	var request = map[string]string{"token": member.Token}
+	var request = map[string]string{"token": member.TokenValue()}
 
-	_, err := sendRequest("DELETE", "/api/service/user", request)
+	_, err := member.getClient().sendRequest("DELETE", "/api/service/user", request)
 	if err != nil {
 		return err
 	}
@@ -398,9 +966,9 @@ func (member *Member) Delete() error {
 
 // Logs out the member.  The member object cannot be used after this call and must be recreated.
 func (member *Member) Logout() error {
-	var request = map[string]string{"token": member.Token, "public_key": member.PublicKey}
+	var request = map[string]string{"token": member.TokenValue(), "public_key": member.PublicKey}
 
-	_, err := sendRequest("POST", "/api/service/logout", request)
+	_, err := member.getClient().sendRequest("POST", "/api/service/logout", request)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,117 @@
+package flexkittest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/Plasso/plasso-go/flexkit"
+)
+
+// The time fixtures are created at, fixed so golden files do not change
+var FixtureTime = time.Date(2024, time.January, 15, 12, 0, 0, 0, time.UTC)
+
+// Every event type, for tests that cover all of them
+var EventTypes = []flexkit.EventType{
+	flexkit.EventMemberCreated,
+	flexkit.EventMemberUpdated,
+	flexkit.EventMemberDeleted,
+	flexkit.EventSubscriptionUpdated,
+	flexkit.EventSubscriptionCancelled,
+	flexkit.EventPaymentSucceeded,
+	flexkit.EventPaymentFailed,
+	flexkit.EventPaymentRefunded,
+}
+
+// Returns a fixture id with the given prefix derived from parts, e.g.
+// "mem_3f2a9c1e".  The same parts always give the same id, whatever else the
+// tests create.
+func fixtureId(prefix string, parts ...string) string {
+	var sum = sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return prefix + "_" + hex.EncodeToString(sum[:4])
+}
+
+// Returns an active member on the "pro" plan with a card, shipping address
+// and data field set.  The id is derived from email.  Change the fields the
+// test cares about.
+//
+//	var data = flexkittest.NewMemberData("jane@example.com")
+//	data.Status = flexkit.SubscriptionPastDue
+func NewMemberData(email string) flexkit.MemberData {
+	return flexkit.MemberData{
+		Id:               fixtureId("mem", email),
+		Email:            email,
+		Name:             "Jane Doe",
+		CreditCardLast4:  "4242",
+		CreditCardType:   "Visa",
+		ShippingName:     "Jane Doe",
+		ShippingAddress:  "1 Main St",
+		ShippingCity:     "Springfield",
+		ShippingState:    "IL",
+		ShippingZip:      "62701",
+		ShippingCountry:  "US",
+		DataFields:       flexkit.DataFields{{Id: "company", Label: "Company", Value: "Acme"}},
+		Plan:             "pro",
+		Status:           flexkit.SubscriptionActive,
+		CurrentPeriodEnd: FixtureTime.AddDate(0, 1, 0),
+		CreatedAt:        FixtureTime,
+		Quantity:         1,
+	}
+}
+
+// Returns a monthly plan costing amount, a decimal string such as "10.00" in USD
+func NewPlan(id string, amount string) flexkit.Plan {
+	var price, err = flexkit.ParseMoney(amount, "USD")
+	if err != nil {
+		panic(err)
+	}
+
+	return flexkit.Plan{Id: id, Name: id, Description: "The " + id + " plan", Amount: price, Interval: "month"}
+}
+
+// Returns an event of the given type about member, with sample data of the
+// shape Plasso sends for that type.  Ids are derived from the type and member,
+// set Id when a test needs several events of the same type about a member.
+func NewEvent(eventType flexkit.EventType, member flexkit.MemberData) flexkit.Event {
+	var data interface{}
+	switch eventType {
+	case flexkit.EventMemberCreated, flexkit.EventMemberUpdated, flexkit.EventMemberDeleted:
+		data = map[string]interface{}{
+			"id":          member.Id,
+			"email":       member.Email,
+			"name":        member.Name,
+			"plan":        member.Plan,
+			"data_fields": member.DataFields,
+		}
+	case flexkit.EventSubscriptionUpdated, flexkit.EventSubscriptionCancelled:
+		data = map[string]interface{}{
+			"plan":                 member.Plan,
+			"status":               member.Status,
+			"quantity":             member.Quantity,
+			"current_period_end":   member.CurrentPeriodEnd,
+			"cancel_at_period_end": member.CancelAtPeriodEnd,
+		}
+	default:
+		data = map[string]interface{}{
+			"payment_id": fixtureId("pay", string(eventType), member.Id),
+			"amount":     "10.00",
+			"currency":   "USD",
+			"plan":       member.Plan,
+		}
+	}
+
+	var raw, err = json.Marshal(data)
+	if err != nil {
+		panic(err)
+	}
+
+	return flexkit.Event{
+		Id:        fixtureId("evt", string(eventType), member.Id),
+		Type:      eventType,
+		MemberId:  member.Id,
+		CreatedAt: FixtureTime,
+		Data:      raw,
+	}
+}
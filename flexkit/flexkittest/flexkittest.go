@@ -0,0 +1,885 @@
+/*
+This package provides a fake Plasso server for testing code that uses the flexkit
+package.  The server keeps members, plans and payments in memory and can be told
+to fail requests, so login, payment and subscription flows can be exercised
+without talking to plasso.com.
+
+Example
+
+	func TestSignup(t *testing.T) {
+		var server = flexkittest.NewServer()
+		defer server.Close()
+
+		server.AddPlan("pro")
+		server.AddMember(flexkittest.Member{
+			MemberData: flexkit.MemberData{Id: "1", Email: "mike+1@plasso.com", Plan: "pro"},
+			Password:   "password",
+		})
+
+		var client = server.Client()
+		member, err := client.Login(flexkit.LoginRequest{PublicKey: "test", Email: "mike+1@plasso.com", Password: "password"})
+		...
+	}
+*/
+package flexkittest
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Plasso/plasso-go/flexkit"
+)
+
+// A member known to the fake server
+type Member struct {
+	flexkit.MemberData
+	Password  string // Password the member logs in with
+	PublicKey string // Public key of the space, any key is accepted when empty
+}
+
+// A fake Plasso server.  It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mutex    sync.Mutex
+	members  map[string]*Member // keyed by email
+	tokens   map[string]*Member // keyed by member token
+	plans    map[string]bool
+	free     map[string]bool // plans that can be subscribed to without payment
+	failures map[string]int  // status codes keyed by path
+	payments []flexkit.PaymentRequest
+	guests   map[string]flexkit.PaymentRequest // guest payments keyed by claim token
+	changes  map[string]emailChange            // pending email changes keyed by confirmation token
+	links    map[string]*Member                // unused login links keyed by token
+	factors  map[*Member]*twoFactor            // two-factor enrollments
+	pending  map[string]*Member                // logins waiting for a two-factor code keyed by challenge
+	oauth    map[string]*Member                // social sign-in identities keyed by provider and token
+	sessions map[string]*session               // keyed by member token
+	lockout  lockout
+	signer   *ecdsa.PrivateKey      // signs member tokens, set by SignTokens
+	replies  map[string]interface{} // responses keyed by idempotency key
+	nextId   int
+}
+
+type userRequest struct {
+	Token           string             `json:"pltoken"`
+	Email           string             `json:"email"`
+	Name            string             `json:"name"`
+	ShippingName    string             `json:"shipping_name"`
+	ShippingAddress string             `json:"shipping_address"`
+	ShippingCity    string             `json:"shipping_city"`
+	ShippingState   string             `json:"shipping_state"`
+	ShippingZip     string             `json:"shipping_zip"`
+	ShippingCountry string             `json:"shipping_country"`
+	ShippingOptions string             `json:"shipping_options"`
+	Last4           string             `json:"cc_last_4"`
+	Type            string             `json:"cc_type"`
+	PlanId          string             `json:"plan"`
+	DataFields      []flexkit.DataItem `json:"data_fields"`
+	Code            string             `json:"code"`
+}
+
+// Locks accounts after failed logins, see LockAfter
+type lockout struct {
+	attempts int                  // failed logins in a row that lock an account, 0 to never lock
+	duration time.Duration        // how long an account stays locked
+	failed   map[string]int       // failed logins in a row keyed by email
+	until    map[string]time.Time // end of lockouts keyed by email
+}
+
+type session struct {
+	id       string
+	device   string
+	ip       string
+	lastSeen time.Time
+}
+
+type emailChange struct {
+	member *Member
+	email  string
+}
+
+type sessionRequest struct {
+	Token     string `json:"token"`
+	PublicKey string `json:"public_key"`
+}
+
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+// Starts a new fake server.  Close it when done.
+func NewServer() *Server {
+	var server = &Server{
+		members:  map[string]*Member{},
+		tokens:   map[string]*Member{},
+		plans:    map[string]bool{},
+		free:     map[string]bool{},
+		guests:   map[string]flexkit.PaymentRequest{},
+		changes:  map[string]emailChange{},
+		links:    map[string]*Member{},
+		factors:  map[*Member]*twoFactor{},
+		pending:  map[string]*Member{},
+		oauth:    map[string]*Member{},
+		sessions: map[string]*session{},
+		lockout:  lockout{failed: map[string]int{}, until: map[string]time.Time{}},
+		failures: map[string]int{},
+		replies:  map[string]interface{}{},
+	}
+
+	var mux = http.NewServeMux()
+	mux.HandleFunc("/api/service/login", server.login)
+	mux.HandleFunc("/api/service/logout", server.logout)
+	mux.HandleFunc("/api/service/claim", server.claim)
+	mux.HandleFunc("/api/service/login/2fa", server.completeLogin)
+	mux.HandleFunc("/api/service/login/oauth", server.oauthLogin)
+	mux.HandleFunc("/api/service/login_link", server.sendLoginLink)
+	mux.HandleFunc("/api/service/login_link/exchange", server.exchangeLoginLink)
+	mux.HandleFunc("/api/service/email/confirm", server.confirmEmail)
+	mux.HandleFunc("/api/service/jwks", server.jwks)
+	mux.HandleFunc("/api/service/user", server.deleteUser)
+	mux.HandleFunc("/api/services/user", server.updateUser)
+	mux.HandleFunc("/api/payments", server.createPayment)
+	mux.HandleFunc("/api/subscriptions", server.createSubscription)
+	mux.HandleFunc("/graphql", server.graphQL)
+
+	server.Server = httptest.NewServer(server.fail(mux))
+	return server
+}
+
+// Returns a flexkit client that talks to this server
+func (server *Server) Client(options ...flexkit.Option) *flexkit.Client {
+	options = append([]flexkit.Option{flexkit.WithBaseURL(server.URL)}, options...)
+	return flexkit.NewClient(options...)
+}
+
+// Adds a member that can log in
+func (server *Server) AddMember(member Member) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	if member.Id == "" {
+		member.Id = server.newId()
+	}
+	server.members[member.Email] = &member
+}
+
+// Returns the current state of a member
+func (server *Server) Member(email string) (Member, bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	var member, ok = server.members[email]
+	if !ok {
+		return Member{}, false
+	}
+
+	return *member, true
+}
+
+// Adds a plan members can subscribe to
+func (server *Server) AddPlan(id string) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.plans[id] = true
+}
+
+// Adds a free plan, which members can also subscribe to with
+// CreateFreeSubscription
+func (server *Server) AddFreePlan(id string) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.plans[id] = true
+	server.free[id] = true
+}
+
+// Makes providerToken of provider log in the member with email through
+// LoginWithOAuth.  The member must have been added with AddMember.
+func (server *Server) AddOAuthToken(provider flexkit.OAuthProvider, providerToken string, email string) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.oauth[string(provider)+":"+providerToken] = server.members[email]
+}
+
+// Locks an account for duration once attempts logins in a row failed with a
+// wrong password.  Logins to a locked account fail with a 423 and the
+// account_locked error, even with the right password.
+func (server *Server) LockAfter(attempts int, duration time.Duration) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.lockout.attempts = attempts
+	server.lockout.duration = duration
+}
+
+// Makes every request to path fail with status until ClearFailures is called.
+// The path does not include the query string, e.g. "/api/payments".
+func (server *Server) Fail(path string, status int) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.failures[path] = status
+}
+
+// Removes all failures added with Fail
+func (server *Server) ClearFailures() {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.failures = map[string]int{}
+}
+
+// Returns every payment the server accepted
+func (server *Server) Payments() []flexkit.PaymentRequest {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return append([]flexkit.PaymentRequest(nil), server.payments...)
+}
+
+func (server *Server) fail(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.mutex.Lock()
+		var status, ok = server.failures[r.URL.Path]
+		server.mutex.Unlock()
+
+		if ok {
+			writeError(w, status, "simulated failure")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (server *Server) login(w http.ResponseWriter, r *http.Request) {
+	var request flexkit.LoginRequest
+	if !decode(w, r, "POST", &request) {
+		return
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	if until, locked := server.lockout.until[request.Email]; locked && time.Now().Before(until) {
+		writeJSON(w, http.StatusLocked, map[string]interface{}{
+			"error":       "account_locked",
+			"retry_after": int(math.Ceil(time.Until(until).Seconds())),
+		})
+		return
+	}
+
+	var member, ok = server.members[request.Email]
+	if !ok || member.Password != request.Password {
+		server.lockout.failed[request.Email]++
+		if server.lockout.attempts > 0 && server.lockout.failed[request.Email] >= server.lockout.attempts {
+			server.lockout.until[request.Email] = time.Now().Add(server.lockout.duration)
+			server.lockout.failed[request.Email] = 0
+		}
+		writeError(w, http.StatusUnauthorized, "invalid email or password")
+		return
+	}
+	delete(server.lockout.failed, request.Email)
+	if member.PublicKey != "" && member.PublicKey != request.PublicKey {
+		writeError(w, http.StatusUnauthorized, "invalid public key")
+		return
+	}
+
+	server.loggedIn(w, member)
+}
+
+func (server *Server) oauthLogin(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		PublicKey string                `json:"public_key"`
+		Provider  flexkit.OAuthProvider `json:"provider"`
+		Token     string                `json:"token"`
+	}
+	if !decode(w, r, "POST", &request) {
+		return
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	var member = server.oauth[string(request.Provider)+":"+request.Token]
+	if member == nil {
+		writeError(w, http.StatusUnauthorized, "invalid provider token")
+		return
+	}
+
+	server.loggedIn(w, member)
+}
+
+// Replies with a token for member, or with a challenge if they have two-factor
+// authentication enabled.  Must be called with the mutex held.
+func (server *Server) loggedIn(w http.ResponseWriter, member *Member) {
+	if factor := server.factors[member]; factor != nil && factor.enabled {
+		var challenge = server.newId()
+		server.pending[challenge] = member
+		writeJSON(w, http.StatusOK, map[string]string{"two_factor_challenge": challenge})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"token": server.newToken(member)})
+}
+
+func (server *Server) sendLoginLink(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Email     string `json:"email"`
+		PublicKey string `json:"public_key"`
+	}
+	if !decode(w, r, "POST", &request) {
+		return
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	if member, ok := server.members[request.Email]; ok {
+		server.links[server.newId()] = member
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{})
+}
+
+// Returns the token of the latest login link sent to email by SendLoginLink,
+// as the member would find it in their inbox
+func (server *Server) LoginLinkToken(email string) (string, bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	var latest = 0
+	for token, member := range server.links {
+		var id, _ = strconv.Atoi(token)
+		if member.Email == email && id > latest {
+			latest = id
+		}
+	}
+
+	return strconv.Itoa(latest), latest > 0
+}
+
+func (server *Server) exchangeLoginLink(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Token     string `json:"token"`
+		PublicKey string `json:"public_key"`
+	}
+	if !decode(w, r, "POST", &request) {
+		return
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	var member, ok = server.links[request.Token]
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "invalid or used login link")
+		return
+	}
+	delete(server.links, request.Token)
+
+	writeJSON(w, http.StatusOK, map[string]string{"token": server.newToken(member)})
+}
+
+func (server *Server) logout(w http.ResponseWriter, r *http.Request) {
+	var request sessionRequest
+	if !decode(w, r, "POST", &request) {
+		return
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	if _, ok := server.tokens[request.Token]; !ok {
+		writeError(w, http.StatusUnauthorized, "invalid token")
+		return
+	}
+	delete(server.tokens, request.Token)
+	delete(server.sessions, request.Token)
+
+	writeJSON(w, http.StatusOK, map[string]string{})
+}
+
+func (server *Server) deleteUser(w http.ResponseWriter, r *http.Request) {
+	var request sessionRequest
+	if !decode(w, r, "DELETE", &request) {
+		return
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	var member, ok = server.tokens[request.Token]
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "invalid token")
+		return
+	}
+	delete(server.members, member.Email)
+	for token, m := range server.tokens {
+		if m == member {
+			delete(server.tokens, token)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{})
+}
+
+func (server *Server) updateUser(w http.ResponseWriter, r *http.Request) {
+	var request userRequest
+	if !decode(w, r, "POST", &request) {
+		return
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	var member, ok = server.tokens[request.Token]
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "invalid token")
+		return
+	}
+
+	switch r.URL.Query().Get("action") {
+	case "settings":
+		if request.Email != "" && request.Email != member.Email {
+			if _, taken := server.members[request.Email]; taken {
+				writeError(w, http.StatusConflict, "email already in use")
+				return
+			}
+			delete(server.members, member.Email)
+			member.Email = request.Email
+			server.members[member.Email] = member
+		}
+		if request.Name != "" {
+			member.Name = request.Name
+		}
+		member.ShippingName = request.ShippingName
+		member.ShippingAddress = request.ShippingAddress
+		member.ShippingCity = request.ShippingCity
+		member.ShippingState = request.ShippingState
+		member.ShippingZip = request.ShippingZip
+		member.ShippingCountry = request.ShippingCountry
+		member.ShippingOptions = request.ShippingOptions
+	case "email_change":
+		if _, taken := server.members[request.Email]; taken {
+			writeError(w, http.StatusConflict, "email already in use")
+			return
+		}
+		server.changes[server.newId()] = emailChange{member: member, email: request.Email}
+	case "logout_all":
+		for token := range server.tokens {
+			if server.tokens[token] == member {
+				delete(server.tokens, token)
+				delete(server.sessions, token)
+			}
+		}
+	case "2fa_enable":
+		server.enableTwoFactor(w, member)
+		return
+	case "2fa_confirm":
+		server.confirmTwoFactor(w, member, request.Code)
+		return
+	case "cc":
+		if request.PlanId != "" {
+			if !server.plans[request.PlanId] {
+				writeError(w, http.StatusBadRequest, "unknown plan")
+				return
+			}
+			member.Plan = request.PlanId
+		}
+		member.CreditCardLast4 = request.Last4
+		member.CreditCardType = request.Type
+	case "data_fields":
+		for _, item := range request.DataFields {
+			var found = false
+			for i := range member.DataFields {
+				if member.DataFields[i].Id == item.Id {
+					member.DataFields[i].Value = item.Value
+					found = true
+				}
+			}
+			if !found {
+				member.DataFields = append(member.DataFields, item)
+			}
+		}
+	default:
+		writeError(w, http.StatusBadRequest, "unknown action")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{})
+}
+
+func (server *Server) createPayment(w http.ResponseWriter, r *http.Request) {
+	var request flexkit.PaymentRequest
+	if !decode(w, r, "POST", &request) {
+		return
+	}
+
+	if request.Token == "" {
+		writeError(w, http.StatusBadRequest, "missing token")
+		return
+	}
+	if len(request.Products) == 0 {
+		writeError(w, http.StatusBadRequest, "missing products")
+		return
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	if server.replay(w, r) {
+		return
+	}
+
+	server.payments = append(server.payments, request)
+	if r.URL.Query().Get("action") == "guest" {
+		var claimToken = server.newId()
+		server.guests[claimToken] = request
+		server.reply(w, r, map[string]string{"order_id": server.newId(), "claim_token": claimToken})
+		return
+	}
+
+	server.reply(w, r, map[string]string{})
+}
+
+// Returns the token of the confirmation link sent to newEmail by
+// Member.RequestEmailChange, as the member would find it in their inbox
+func (server *Server) EmailChangeToken(newEmail string) (string, bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	for token, change := range server.changes {
+		if change.email == newEmail {
+			return token, true
+		}
+	}
+
+	return "", false
+}
+
+func (server *Server) confirmEmail(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Token     string `json:"token"`
+		PublicKey string `json:"public_key"`
+	}
+	if !decode(w, r, "POST", &request) {
+		return
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	var change, ok = server.changes[request.Token]
+	if !ok {
+		writeError(w, http.StatusNotFound, "unknown token")
+		return
+	}
+	if _, taken := server.members[change.email]; taken {
+		writeError(w, http.StatusConflict, "email already in use")
+		return
+	}
+	delete(server.changes, request.Token)
+
+	delete(server.members, change.member.Email)
+	change.member.Email = change.email
+	server.members[change.member.Email] = change.member
+
+	writeJSON(w, http.StatusOK, map[string]string{})
+}
+
+func (server *Server) claim(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		ClaimToken string `json:"claim_token"`
+		Password   string `json:"password"`
+		PublicKey  string `json:"public_key"`
+	}
+	if !decode(w, r, "POST", &request) {
+		return
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	var payment, ok = server.guests[request.ClaimToken]
+	if !ok {
+		writeError(w, http.StatusNotFound, "unknown claim token")
+		return
+	}
+	if _, taken := server.members[payment.Email]; taken {
+		writeError(w, http.StatusConflict, "email already in use")
+		return
+	}
+	delete(server.guests, request.ClaimToken)
+
+	var member = &Member{
+		MemberData: flexkit.MemberData{Id: server.newId(), Email: payment.Email, Name: payment.Name, CreatedAt: time.Now()},
+		Password:   request.Password,
+		PublicKey:  request.PublicKey,
+	}
+	server.members[member.Email] = member
+
+	writeJSON(w, http.StatusOK, map[string]string{"token": server.newToken(member)})
+}
+
+func (server *Server) createSubscription(w http.ResponseWriter, r *http.Request) {
+	var request flexkit.SubscriptionRequest
+	if !decode(w, r, "POST", &request) {
+		return
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	if server.replay(w, r) {
+		return
+	}
+
+	if !server.plans[request.Plan] {
+		writeError(w, http.StatusBadRequest, "unknown plan")
+		return
+	}
+	if r.URL.Query().Get("action") == "free" && !server.free[request.Plan] {
+		writeError(w, http.StatusPaymentRequired, "plan is not free")
+		return
+	}
+	if request.Email == "" {
+		writeError(w, http.StatusBadRequest, "missing email")
+		return
+	}
+	if _, taken := server.members[request.Email]; taken {
+		writeError(w, http.StatusConflict, "email already in use")
+		return
+	}
+
+	var member = &Member{
+		MemberData: flexkit.MemberData{
+			Id:              server.newId(),
+			Email:           request.Email,
+			Name:            request.Name,
+			ShippingName:    request.Shipping.Name,
+			ShippingAddress: request.Shipping.Street,
+			ShippingCity:    request.Shipping.City,
+			ShippingState:   request.Shipping.State,
+			ShippingZip:     request.Shipping.Zip,
+			ShippingCountry: request.Shipping.Country,
+			ShippingOptions: request.ShippingOptions,
+			DataFields:      request.DataFields,
+			Plan:            request.Plan,
+		},
+		Password:  request.Password,
+		PublicKey: request.PublicKey,
+	}
+	member.Status = flexkit.SubscriptionActive
+	member.Quantity = 1
+	member.CreatedAt = time.Now()
+	member.CurrentPeriodEnd = member.CreatedAt.AddDate(0, 1, 0)
+	if request.TrialDays > 0 {
+		member.Status = flexkit.SubscriptionTrialing
+		member.TrialEnd = member.CreatedAt.AddDate(0, 0, request.TrialDays)
+		member.CurrentPeriodEnd = member.TrialEnd
+	}
+	server.members[member.Email] = member
+
+	server.reply(w, r, map[string]string{"token": server.newToken(member)})
+}
+
+// Writes the stored response of a request with a known Idempotency-Key.  Must
+// be called with the mutex held.
+func (server *Server) replay(w http.ResponseWriter, r *http.Request) bool {
+	var key = r.Header.Get("Idempotency-Key")
+	var body, ok = server.replies[key]
+	if key == "" || !ok {
+		return false
+	}
+
+	writeJSON(w, http.StatusOK, body)
+	return true
+}
+
+// Writes a successful response and stores it for replay.  Must be called with
+// the mutex held.
+func (server *Server) reply(w http.ResponseWriter, r *http.Request, body interface{}) {
+	var key = r.Header.Get("Idempotency-Key")
+	if key != "" {
+		server.replies[key] = body
+	}
+
+	writeJSON(w, http.StatusOK, body)
+}
+
+func (server *Server) graphQL(w http.ResponseWriter, r *http.Request) {
+	var request graphQLRequest
+	if !decode(w, r, "POST", &request) {
+		return
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	var token, _ = request.Variables["token"].(string)
+	var member, ok = server.tokens[token]
+	if !ok {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"data":   map[string]interface{}{"member": nil},
+			"errors": []map[string]string{{"message": "invalid token"}},
+		})
+		return
+	}
+	server.touch(token, r)
+
+	if strings.Contains(request.Query, "sessions") {
+		server.listSessions(w, member, token)
+		return
+	}
+	if strings.Contains(request.Query, "validateToken") {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{"member": map[string]string{"id": member.Id}},
+		})
+		return
+	}
+
+	var dataFields = member.DataFields
+	if dataFields == nil {
+		dataFields = []flexkit.DataItem{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{
+			"member": map[string]interface{}{
+				"id":      member.Id,
+				"name":    member.Name,
+				"email":   member.Email,
+				"ccType":  member.CreditCardType,
+				"ccLast4": member.CreditCardLast4,
+				"shippingInfo": map[string]string{
+					"name":    member.ShippingName,
+					"address": member.ShippingAddress,
+					"city":    member.ShippingCity,
+					"state":   member.ShippingState,
+					"zip":     member.ShippingZip,
+					"country": member.ShippingCountry,
+				},
+				"dataFields":        dataFields,
+				"plan":              map[string]string{"alias": member.Plan},
+				"trialEnd":          timeOrNil(member.TrialEnd),
+				"status":            member.Status,
+				"currentPeriodEnd":  timeOrNil(member.CurrentPeriodEnd),
+				"cancelAtPeriodEnd": member.CancelAtPeriodEnd,
+				"createdAt":         timeOrNil(member.CreatedAt),
+				"paymentFailedAt":   timeOrNil(member.PaymentFailedAt),
+				"nextPaymentRetry":  timeOrNil(member.NextPaymentRetry),
+				"quantity":          member.Quantity,
+			},
+		},
+	})
+}
+
+// Returns nil for the zero time, which the API sends as null
+func timeOrNil(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+
+	return t
+}
+
+// Must be called with the mutex held
+func (server *Server) newId() string {
+	server.nextId++
+	return strconv.Itoa(server.nextId)
+}
+
+// Must be called with the mutex held
+func (server *Server) newToken(member *Member) string {
+	var buffer = make([]byte, 16)
+	if _, err := rand.Read(buffer); err != nil {
+		panic(err)
+	}
+
+	var token = hex.EncodeToString(buffer)
+	if server.signer != nil {
+		token = server.signToken(member, token)
+	}
+	server.tokens[token] = member
+	server.sessions[token] = &session{id: server.newId(), lastSeen: time.Now()}
+	return token
+}
+
+// Records a use of the session of token by r.  Must be called with the mutex
+// held.
+func (server *Server) touch(token string, r *http.Request) {
+	var session = server.sessions[token]
+	if session == nil {
+		return
+	}
+
+	session.device = r.UserAgent()
+	session.ip, _, _ = net.SplitHostPort(r.RemoteAddr)
+	session.lastSeen = time.Now()
+}
+
+// Must be called with the mutex held
+func (server *Server) listSessions(w http.ResponseWriter, member *Member, current string) {
+	var sessions = []map[string]interface{}{}
+	for token, session := range server.sessions {
+		if server.tokens[token] != member {
+			continue
+		}
+		sessions = append(sessions, map[string]interface{}{
+			"id":         session.id,
+			"device":     session.device,
+			"ip":         session.ip,
+			"lastSeenAt": session.lastSeen,
+			"current":    token == current,
+		})
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i]["lastSeenAt"].(time.Time).After(sessions[j]["lastSeenAt"].(time.Time))
+	})
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{"member": map[string]interface{}{"sessions": sessions}},
+	})
+}
+
+func decode(w http.ResponseWriter, r *http.Request, method string, request interface{}) bool {
+	if r.Method != method {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("expected %s", method))
+		return false
+	}
+
+	var err = json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+
+	return true
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
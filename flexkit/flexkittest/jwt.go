@@ -0,0 +1,97 @@
+package flexkittest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// Id of the key the server signs member tokens with
+const signingKeyId string = "flexkittest"
+
+// Makes the server issue member tokens that are JWTs, signed with a key it
+// publishes for flexkit.TokenVerifier to check them locally.  Tokens issued
+// before the call stay opaque.
+func (server *Server) SignTokens() {
+	var key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.signer = key
+}
+
+// Returns a JWT for member, with id as its unique id.  Must be called with the
+// mutex held.
+func (server *Server) signToken(member *Member, id string) string {
+	var now = time.Now()
+	var claims = map[string]interface{}{
+		"sub": member.Id,
+		"iat": now.Unix(),
+		"exp": now.Add(time.Hour).Unix(),
+		"jti": id,
+	}
+	if member.PublicKey != "" {
+		claims["aud"] = member.PublicKey
+	}
+
+	var signed = encodeSegment(map[string]string{"alg": "ES256", "kid": signingKeyId, "typ": "JWT"}) + "." + encodeSegment(claims)
+	var digest = sha256.Sum256([]byte(signed))
+	r, s, err := ecdsa.Sign(rand.Reader, server.signer, digest[:])
+	if err != nil {
+		panic(err)
+	}
+
+	var signature = make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func encodeSegment(value interface{}) string {
+	var data, err = json.Marshal(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func (server *Server) jwks(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		PublicKey string `json:"public_key"`
+	}
+	if !decode(w, r, "POST", &request) {
+		return
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	var keys = []map[string]string{}
+	if server.signer != nil {
+		var x, y = make([]byte, 32), make([]byte, 32)
+		server.signer.PublicKey.X.FillBytes(x)
+		server.signer.PublicKey.Y.FillBytes(y)
+		keys = append(keys, map[string]string{
+			"kid": signingKeyId,
+			"kty": "EC",
+			"alg": "ES256",
+			"use": "sig",
+			"crv": "P-256",
+			"x":   base64.RawURLEncoding.EncodeToString(x),
+			"y":   base64.RawURLEncoding.EncodeToString(y),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"keys": keys})
+}
@@ -0,0 +1,297 @@
+/*
+This package records calls to the Plasso API into fixture files and replays
+them, so tests of code using the flexkit package run without live credentials
+and give the same result every time.  Tokens, passwords, keys and card data
+are scrubbed before anything is written.
+
+Example
+
+	func TestLogin(t *testing.T) {
+		var mode = recorder.Replay
+		if os.Getenv("FLEXKIT_RECORD") != "" {
+			mode = recorder.Record
+		}
+
+		rec, err := recorder.New("testdata/login.json", mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rec.Save()
+
+		var client = flexkit.NewClient(flexkit.WithRoundTripper(rec))
+		member, err := client.Login(flexkit.LoginRequest{PublicKey: "...", Email: "...", Password: "..."})
+		...
+	}
+*/
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/Plasso/plasso-go/flexkit"
+)
+
+// Whether a Recorder talks to the API or replays a fixture
+type Mode int
+
+const (
+	Replay Mode = iota // Answer requests from the fixture file, never touch the network
+	Record             // Send requests to the API and keep them for Save
+)
+
+// Returned by Unused when interactions of the fixture were not replayed
+var ErrUnused = errors.New("recorder: recorded interactions were not replayed")
+
+// The value scrubbed fields are replaced with
+const Scrubbed string = "[scrubbed]"
+
+// JSON fields that are scrubbed from request and response bodies, the same
+// ones the flexkit client keeps out of its logs
+var scrubbedFields = func() map[string]bool {
+	var fields = map[string]bool{}
+	for _, field := range flexkit.SensitiveFields() {
+		fields[field] = true
+	}
+
+	return fields
+}()
+
+// Headers that are never written to fixtures
+var scrubbedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// A recorded request and the response it got
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// A recorded request, with scrubbed body
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body"`
+}
+
+// A recorded response, with scrubbed headers and body
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+}
+
+// An http.RoundTripper that records or replays interactions with the API.  It
+// is safe for concurrent use.
+type Recorder struct {
+	Transport http.RoundTripper // Sends requests when recording, http.DefaultTransport if nil
+
+	path         string
+	mode         Mode
+	mutex        sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// Creates a recorder for the fixture at path.  In Replay mode the fixture is
+// read now and must exist.
+func New(path string, mode Mode) (*Recorder, error) {
+	var recorder = &Recorder{path: path, mode: mode}
+	if mode == Record {
+		return recorder, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &recorder.interactions)
+	if err != nil {
+		return nil, fmt.Errorf("recorder: %s: %w", path, err)
+	}
+	recorder.used = make([]bool, len(recorder.interactions))
+
+	return recorder, nil
+}
+
+func (recorder *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body, err = readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var request = Request{Method: req.Method, URL: req.URL.RequestURI(), Body: scrub(body)}
+	if recorder.mode == Replay {
+		return recorder.replay(req, request)
+	}
+
+	return recorder.record(req, request)
+}
+
+// Answers req with the first unused interaction recorded for the same request
+func (recorder *Recorder) replay(req *http.Request, request Request) (*http.Response, error) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	for i, interaction := range recorder.interactions {
+		if recorder.used[i] || interaction.Request != request {
+			continue
+		}
+		recorder.used[i] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("recorder: no recorded interaction for %s %s in %s", request.Method, request.URL, recorder.path)
+}
+
+// Sends req and keeps the scrubbed interaction
+func (recorder *Recorder) record(req *http.Request, request Request) (*http.Response, error) {
+	var transport = recorder.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	var header = res.Header.Clone()
+	for _, key := range scrubbedHeaders {
+		header.Del(key)
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	recorder.interactions = append(recorder.interactions, Interaction{
+		Request:  request,
+		Response: Response{StatusCode: res.StatusCode, Header: header, Body: scrub(body)},
+	})
+
+	return res, nil
+}
+
+// Writes the recorded interactions to the fixture file.  Does nothing in
+// Replay mode.
+func (recorder *Recorder) Save() error {
+	if recorder.mode == Replay {
+		return nil
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	data, err := json.MarshalIndent(recorder.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(recorder.path), 0755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(recorder.path, append(data, '\n'), 0644)
+}
+
+// Reads the body of req and puts it back for sending
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+// Replaces the values of scrubbed fields anywhere in a JSON body.  Bodies that
+// are not JSON are kept as they are.
+func scrub(body []byte) string {
+	var value interface{}
+	var decoder = json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var err = decoder.Decode(&value)
+	if err != nil || decoder.More() {
+		return string(body)
+	}
+
+	scrubbed, err := json.Marshal(scrubValue(value))
+	if err != nil {
+		return string(body)
+	}
+
+	return string(scrubbed)
+}
+
+func scrubValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if scrubbedFields[key] {
+				value[key] = Scrubbed
+			} else {
+				value[key] = scrubValue(field)
+			}
+		}
+	case []interface{}:
+		for i := range value {
+			value[i] = scrubValue(value[i])
+		}
+	}
+
+	return value
+}
+
+// Reports interactions of the fixture no request asked for, which usually
+// means the code under test made fewer calls than when it was recorded
+func (recorder *Recorder) Unused() error {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	var unused []string
+	for i, used := range recorder.used {
+		if !used {
+			var request = recorder.interactions[i].Request
+			unused = append(unused, request.Method+" "+request.URL)
+		}
+	}
+
+	if len(unused) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrUnused, strings.Join(unused, ", "))
+}
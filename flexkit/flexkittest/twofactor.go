@@ -0,0 +1,121 @@
+package flexkittest
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// A member's two-factor enrollment
+type twoFactor struct {
+	secret  []byte
+	enabled bool // false until confirmed with a code
+}
+
+// Returns the TOTP code of secret at t, as an authenticator app shows it
+func totp(secret []byte, t time.Time) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(t.Unix()/30))
+
+	var mac = hmac.New(sha1.New, secret)
+	mac.Write(counter[:])
+	var sum = mac.Sum(nil)
+
+	var offset = sum[len(sum)-1] & 0xf
+	var code = binary.BigEndian.Uint32(sum[offset:]) & 0x7fffffff
+	return fmt.Sprintf("%06d", code%1000000)
+}
+
+// Whether code is the code of secret now, or of the step before or after to
+// allow for clock drift
+func validCode(secret []byte, code string) bool {
+	var now = time.Now()
+	for _, step := range []time.Duration{-30 * time.Second, 0, 30 * time.Second} {
+		if hmac.Equal([]byte(totp(secret, now.Add(step))), []byte(code)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns the current code of the authenticator app of the member with email,
+// once they called Enable2FA
+func (server *Server) TwoFactorCode(email string) (string, bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	var member, ok = server.members[email]
+	if !ok || server.factors[member] == nil {
+		return "", false
+	}
+
+	return totp(server.factors[member].secret, time.Now()), true
+}
+
+// Must be called with the mutex held
+func (server *Server) enableTwoFactor(w http.ResponseWriter, member *Member) {
+	if factor := server.factors[member]; factor != nil && factor.enabled {
+		writeError(w, http.StatusConflict, "two-factor authentication already enabled")
+		return
+	}
+
+	var secret = make([]byte, 20)
+	if _, err := rand.Read(secret); err != nil {
+		panic(err)
+	}
+	server.factors[member] = &twoFactor{secret: secret}
+
+	var uri = fmt.Sprintf("otpauth://totp/Plasso:%s?secret=%s&issuer=Plasso",
+		url.PathEscape(member.Email), base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(secret))
+	writeJSON(w, http.StatusOK, map[string]string{"provisioning_uri": uri})
+}
+
+// Must be called with the mutex held
+func (server *Server) confirmTwoFactor(w http.ResponseWriter, member *Member, code string) {
+	var factor = server.factors[member]
+	if factor == nil {
+		writeError(w, http.StatusBadRequest, "two-factor authentication not started")
+		return
+	}
+	if !validCode(factor.secret, code) {
+		writeError(w, http.StatusBadRequest, "invalid code")
+		return
+	}
+	factor.enabled = true
+
+	writeJSON(w, http.StatusOK, map[string]string{})
+}
+
+func (server *Server) completeLogin(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		PublicKey string `json:"public_key"`
+		Challenge string `json:"challenge"`
+		Code      string `json:"code"`
+	}
+	if !decode(w, r, "POST", &request) {
+		return
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	var member, ok = server.pending[request.Challenge]
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "invalid or expired challenge")
+		return
+	}
+	if !validCode(server.factors[member].secret, request.Code) {
+		writeError(w, http.StatusUnauthorized, "invalid code")
+		return
+	}
+	delete(server.pending, request.Challenge)
+
+	writeJSON(w, http.StatusOK, map[string]string{"token": server.newToken(member)})
+}
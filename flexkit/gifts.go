@@ -0,0 +1,78 @@
+package flexkit
+
+import "time"
+
+// The structure that should be filled out and passed to the CreateGiftSubscription function.
+type GiftRequest struct {
+	PublicKey      string  `json:"public_key"`      // Plasso customer public key
+	Token          string  `json:"token"`           // Token returned from javascript flexkit GetToken call
+	Plan           string  `json:"plan"`            // The plan id that is gifted
+	Months         int     `json:"months"`          // How many months of the plan are gifted
+	Email          string  `json:"email"`           // Email of the purchaser
+	Name           string  `json:"name"`            // Name of the purchaser
+	RecipientEmail string  `json:"recipient_email"` // Where the gift code is sent, the purchaser gets it when empty (optional)
+	Message        string  `json:"message"`         // Message sent with the gift code (optional)
+	Billing        Address `json:"-"`               // Billing address of the purchaser (optional depending on plan).
+	IdempotencyKey string  `json:"-"`               // Retries with the same key are only charged once (optional)
+}
+
+// A purchased gift subscription
+type Gift struct {
+	Code           string    `json:"code"`            // Code the recipient redeems with RedeemGift
+	Plan           string    `json:"plan"`            // The plan id that is gifted
+	Months         int       `json:"months"`          // How many months of the plan are gifted
+	RecipientEmail string    `json:"recipient_email"` // Where the gift code was sent
+	ExpiresAt      time.Time `json:"expires_at"`      // When the code can no longer be redeemed
+}
+
+// Charges the purchaser for a gift subscription and returns the code to redeem it
+func (c *Client) CreateGiftSubscription(request GiftRequest) (*Gift, error) {
+	request.PublicKey = c.requestPublicKey(request.PublicKey)
+	body, err := c.send(c.context(), "POST", "/api/subscriptions?action=gift", c.idempotencyHeader(request.IdempotencyKey), request)
+	if err != nil {
+		return nil, err
+	}
+
+	var gift Gift
+	err = c.decode(body, &gift)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gift, nil
+}
+
+// A request to redeem a gift, sent with the gift code next to the fields of
+// the subscription request so the code stays out of URLs and logs
+type giftRedemption struct {
+	request SubscriptionRequest
+	code    string
+}
+
+func (wire giftRedemption) MarshalJSON() ([]byte, error) {
+	return marshalWithField(wire.request, "gift_code", wire.code)
+}
+
+func (wire giftRedemption) Validate() error {
+	return validateRequest(wire.request)
+}
+
+// Subscribes the recipient of a gift to the gifted plan.  The plan and payment
+// token of the request are not needed, the gift pays for the subscription.
+func (c *Client) RedeemGift(code string, request SubscriptionRequest) (*Member, error) {
+	request.SubscriptionFor = "space"
+	request.PublicKey = c.requestPublicKey(request.PublicKey)
+	var wire = giftRedemption{request, code}
+	body, err := c.send(c.context(), "POST", "/api/subscriptions?action=redeem_gift", c.idempotencyHeader(request.IdempotencyKey), wire)
+	if err != nil {
+		return nil, err
+	}
+
+	var r tokenResponse
+	err = c.decode(body, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.NewMember(request.PublicKey, r.Token), nil
+}
@@ -0,0 +1,70 @@
+package flexkit
+
+// The order of a payment made without an account
+type GuestOrder struct {
+	OrderId    string `json:"order_id"`    // Plasso order id
+	Number     string `json:"number"`      // Order number shown to the customer
+	ClaimToken string `json:"claim_token"` // Pass to ClaimGuestOrder to turn the guest into a member
+	ClaimURL   string `json:"claim_url"`   // Hosted page where the guest can create an account, empty if the space does not offer one
+}
+
+type guestPaymentResponse struct {
+	paymentIntentResponse
+	GuestOrder
+}
+
+type claimRequest struct {
+	PublicKey  string `json:"public_key"`
+	ClaimToken string `json:"claim_token"`
+	Password   string `json:"password"`
+}
+
+// Creates a payment for a customer who does not want an account.  Instead of
+// a member the payment creates an order the customer can claim later, with
+// ClaimGuestOrder or on the page at ClaimURL.  Like CreatePayment it returns
+// an *ActionRequiredError when the customer has to complete an
+// authentication challenge.
+func (c *Client) CreateGuestPayment(request PaymentRequest) (*GuestOrder, error) {
+	request.PublicKey = c.requestPublicKey(request.PublicKey)
+	body, err := c.send(c.context(), "POST", "/api/payments?action=guest", c.idempotencyHeader(request.IdempotencyKey), request)
+	if err != nil {
+		return nil, stockError(body, err)
+	}
+
+	var response guestPaymentResponse
+	err = c.decode(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	var intent = &PaymentIntent{publicKey: request.PublicKey, client: c}
+	err = intent.set(response.paymentIntentResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if intent.Status == PaymentRequiresAction {
+		return nil, &ActionRequiredError{intent}
+	}
+
+	return &response.GuestOrder, nil
+}
+
+// Turns the customer of a guest order into a member with the given password.
+// The member is logged in and sees the order in ListOrders.
+func (c *Client) ClaimGuestOrder(claimToken string, password string) (*Member, error) {
+	var request = claimRequest{PublicKey: c.getPublicKey(), ClaimToken: claimToken, Password: password}
+
+	body, err := c.sendRequest("POST", "/api/service/claim", request)
+	if err != nil {
+		return nil, err
+	}
+
+	var r tokenResponse
+	err = c.decode(body, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.NewMember(request.PublicKey, r.Token), nil
+}
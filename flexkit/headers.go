@@ -0,0 +1,36 @@
+package flexkit
+
+import (
+	"context"
+	"net/http"
+)
+
+type headerKey struct{}
+
+// Sends the header with every request of the client
+func WithHeader(key string, value string) Option {
+	return func(client *Client) {
+		client.header.Add(key, value)
+	}
+}
+
+// Returns a context that makes calls send the header, in addition to the
+// headers of the client.  Use it with WithContext or calls that take a context.
+//
+//	var ctx = flexkit.ContextWithHeader(r.Context(), "X-Partner", "acme")
+//	err = client.WithContext(ctx).CreatePayment(request)
+func ContextWithHeader(ctx context.Context, key string, value string) context.Context {
+	var header = http.Header{}
+	if parent, ok := ctx.Value(headerKey{}).(http.Header); ok {
+		header = parent.Clone()
+	}
+	header.Add(key, value)
+
+	return context.WithValue(ctx, headerKey{}, header)
+}
+
+// Returns the headers added to ctx with ContextWithHeader
+func contextHeader(ctx context.Context) http.Header {
+	var header, _ = ctx.Value(headerKey{}).(http.Header)
+	return header
+}
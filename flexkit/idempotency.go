@@ -0,0 +1,39 @@
+package flexkit
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// Makes CreatePayment and CreateSubscription generate an idempotency key for
+// requests that do not set one.  A generated key only protects against
+// duplicates within a single call, set IdempotencyKey yourself to make retries
+// of your own safe.
+func WithAutoIdempotencyKeys() Option {
+	return func(client *Client) {
+		client.idempotency = true
+	}
+}
+
+// Returns the Idempotency-Key header for key, generating a key if enabled
+func (c *Client) idempotencyHeader(key string) http.Header {
+	if key == "" && c.idempotency {
+		key = newIdempotencyKey()
+	}
+
+	if key == "" {
+		return nil
+	}
+
+	return http.Header{"Idempotency-Key": {key}}
+}
+
+func newIdempotencyKey() string {
+	var buffer = make([]byte, 16)
+	if _, err := rand.Read(buffer); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(buffer)
+}
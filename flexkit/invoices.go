@@ -0,0 +1,310 @@
+package flexkit
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Returned when no invoice has the requested id
+var ErrInvoiceNotFound = errors.New("flexkit: invoice not found")
+
+// Returned by UpcomingInvoice when the member has no renewal coming up
+var ErrNoUpcomingInvoice = errors.New("flexkit: no upcoming invoice")
+
+// Statuses of an invoice
+const (
+	InvoiceDraft         = "draft"
+	InvoiceOpen          = "open"
+	InvoicePaid          = "paid"
+	InvoiceVoid          = "void"
+	InvoiceUncollectible = "uncollectible"
+)
+
+// The invoice fields fetched by invoice queries
+const invoiceSelection string = `
+      id,
+      number,
+      status,
+      currency,
+      subtotal,
+      tax,
+      total,
+      pdfUrl,
+      createdAt,
+      lines {
+        description,
+        quantity,
+        amount,
+        proration
+      }`
+
+const listInvoicesQuery string = `
+query listInvoices($token: String, $first: Int, $after: String) {
+  member(token: $token) {
+    invoices(first: $first, after: $after) {
+      nodes {` + invoiceSelection + `
+      },
+      pageInfo {
+        endCursor,
+        hasNextPage
+      }
+    }
+  }
+}`
+
+const getInvoiceQuery string = `
+query getInvoice($publicKey: String, $id: String) {
+  invoice(publicKey: $publicKey, id: $id) {` + invoiceSelection + `
+  }
+}`
+
+const upcomingInvoiceQuery string = `
+query upcomingInvoice($token: String) {
+  member(token: $token) {
+    upcomingInvoice {
+      currency,
+      subtotal,
+      tax,
+      total,
+      date,
+      coupons,
+      lines {
+        description,
+        quantity,
+        amount,
+        proration
+      }
+    }
+  }
+}`
+
+// An invoice for a member
+type Invoice struct {
+	Id        string        // Plasso invoice id
+	Number    string        // Invoice number shown to the customer
+	Status    string        // One of the Invoice* statuses
+	Subtotal  Money         // Total before tax
+	Tax       Money         // Tax charged
+	Total     Money         // Amount due
+	Lines     []InvoiceLine // What the invoice is for
+	PDFURL    string        // Hosted PDF of the invoice
+	CreatedAt time.Time     // When the invoice was issued
+}
+
+// A line item of an invoice
+type InvoiceLine struct {
+	Description string // What is charged for
+	Quantity    int    // Number of units
+	Amount      Money  // Total of the line
+	Proration   bool   // Whether the line adjusts for a mid-period plan or quantity change
+}
+
+type invoiceNode struct {
+	Id        string            `json:"id"`
+	Number    string            `json:"number"`
+	Status    string            `json:"status"`
+	Currency  string            `json:"currency"`
+	Subtotal  string            `json:"subtotal"`
+	Tax       string            `json:"tax"`
+	Total     string            `json:"total"`
+	PdfUrl    string            `json:"pdfUrl"`
+	CreatedAt time.Time         `json:"createdAt"`
+	Lines     []invoiceLineNode `json:"lines"`
+}
+
+type invoiceLineNode struct {
+	Description string `json:"description"`
+	Quantity    int    `json:"quantity"`
+	Amount      string `json:"amount"`
+	Proration   bool   `json:"proration"`
+}
+
+type pageInfo struct {
+	EndCursor   string `json:"endCursor"`
+	HasNextPage bool   `json:"hasNextPage"`
+}
+
+// Lists the member's invoices, newest first.  Invoices and lines whose amounts
+// cannot be read are left out and logged with WithLogger.
+func (member *Member) ListInvoices() *Pager[Invoice] {
+	return newPager(member.context(), "", func(ctx context.Context, cursor string) ([]Invoice, string, bool, error) {
+		var response struct {
+			Member struct {
+				Invoices struct {
+					Nodes    []invoiceNode `json:"nodes"`
+					PageInfo pageInfo      `json:"pageInfo"`
+				} `json:"invoices"`
+			} `json:"member"`
+		}
+		var variables = map[string]interface{}{
+			"token": member.TokenValue(),
+			"first": defaultPageSize,
+			"after": cursor,
+		}
+
+		var err = member.getClient().GraphQL(ctx, listInvoicesQuery, variables, &response)
+		if err != nil {
+			return nil, "", false, err
+		}
+
+		var client = member.getClient()
+		var invoices = make([]Invoice, 0, len(response.Member.Invoices.Nodes))
+		for _, node := range response.Member.Invoices.Nodes {
+			invoice, err := node.invoice(client)
+			if err != nil {
+				client.logSkipped("invoice", node.Id, err)
+				continue
+			}
+			invoices = append(invoices, invoice)
+		}
+
+		var page = response.Member.Invoices.PageInfo
+		return invoices, page.EndCursor, page.HasNextPage, nil
+	})
+}
+
+// Get an invoice of the space set with WithPublicKey
+func (c *Client) GetInvoice(id string) (*Invoice, error) {
+	var response struct {
+		Invoice *invoiceNode `json:"invoice"`
+	}
+	var variables = map[string]interface{}{"publicKey": c.getPublicKey(), "id": id}
+
+	var err = c.GraphQL(c.context(), getInvoiceQuery, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Invoice == nil {
+		return nil, ErrInvoiceNotFound
+	}
+
+	invoice, err := response.Invoice.invoice(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return &invoice, nil
+}
+
+// Converts node, leaving out lines whose amount cannot be read
+func (node *invoiceNode) invoice(c *Client) (Invoice, error) {
+	var invoice = Invoice{
+		Id:        node.Id,
+		Number:    node.Number,
+		Status:    node.Status,
+		PDFURL:    node.PdfUrl,
+		CreatedAt: node.CreatedAt,
+	}
+
+	var amounts = []struct {
+		value string
+		money *Money
+	}{
+		{node.Subtotal, &invoice.Subtotal},
+		{node.Tax, &invoice.Tax},
+		{node.Total, &invoice.Total},
+	}
+	for _, amount := range amounts {
+		var err = parseAmount(amount.value, node.Currency, amount.money)
+		if err != nil {
+			return Invoice{}, err
+		}
+	}
+
+	invoice.Lines = c.invoiceLines(node.Lines, node.Currency)
+	return invoice, nil
+}
+
+// Converts the lines of an invoice, skipping and logging those whose amount
+// cannot be read
+func (c *Client) invoiceLines(nodes []invoiceLineNode, currency string) []InvoiceLine {
+	var lines []InvoiceLine
+	for _, node := range nodes {
+		var line = InvoiceLine{Description: node.Description, Quantity: node.Quantity, Proration: node.Proration}
+		var err = parseAmount(node.Amount, currency, &line.Amount)
+		if err != nil {
+			c.logSkipped("invoice line", node.Description, err)
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// Parses amount into money, leaving money zero when amount is empty.  Zero
+// decimals past the minor unit of currency, as in "1000.00" JPY, are ignored.
+func parseAmount(amount string, currency string, money *Money) error {
+	if amount == "" {
+		return nil
+	}
+
+	var exponent = currencyExponent(currency)
+	if whole, fraction, ok := strings.Cut(amount, "."); ok && len(fraction) > exponent && strings.Trim(fraction[exponent:], "0") == "" {
+		amount = whole
+		if exponent > 0 {
+			amount += "." + fraction[:exponent]
+		}
+	}
+
+	var err error
+	*money, err = ParseMoney(amount, currency)
+	return err
+}
+
+// The invoice for the next renewal of a member's subscription
+type UpcomingInvoice struct {
+	Subtotal Money         // Total before coupons and tax
+	Tax      Money         // Tax that will be charged
+	Total    Money         // Amount that will be charged
+	Date     time.Time     // When the member will be charged
+	Coupons  []string      // Coupon codes applied to the invoice
+	Lines    []InvoiceLine // What the invoice is for, including prorations
+}
+
+// Previews the next renewal of the member's subscription.  Returns
+// ErrNoUpcomingInvoice when the member has no renewal coming up.  When a total
+// cannot be read the invoice is returned with the error, the other amounts
+// and lines filled in.
+func (member *Member) UpcomingInvoice() (*UpcomingInvoice, error) {
+	var response struct {
+		Member struct {
+			UpcomingInvoice *struct {
+				Currency string            `json:"currency"`
+				Subtotal string            `json:"subtotal"`
+				Tax      string            `json:"tax"`
+				Total    string            `json:"total"`
+				Date     time.Time         `json:"date"`
+				Coupons  []string          `json:"coupons"`
+				Lines    []invoiceLineNode `json:"lines"`
+			} `json:"upcomingInvoice"`
+		} `json:"member"`
+	}
+	var variables = map[string]interface{}{"token": member.TokenValue()}
+
+	var err = member.getClient().GraphQL(member.context(), upcomingInvoiceQuery, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	var node = response.Member.UpcomingInvoice
+	if node == nil {
+		return nil, ErrNoUpcomingInvoice
+	}
+
+	var client = member.getClient()
+	var invoice = UpcomingInvoice{Date: node.Date, Coupons: node.Coupons}
+	invoice.Lines = client.invoiceLines(node.Lines, node.Currency)
+	err = errors.Join(
+		parseAmount(node.Subtotal, node.Currency, &invoice.Subtotal),
+		parseAmount(node.Tax, node.Currency, &invoice.Tax),
+		parseAmount(node.Total, node.Currency, &invoice.Total))
+	if err != nil {
+		return &invoice, err
+	}
+
+	return &invoice, nil
+}
@@ -0,0 +1,38 @@
+package flexkit
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+)
+
+// Returned when a response is larger than the limit set with WithMaxResponseSize
+var ErrResponseTooLarge = errors.New("flexkit: response too large")
+
+const defaultMaxResponseSize int64 = 4 << 20
+
+// Sets the largest response body the client reads, 4 MB by default.  Larger
+// responses fail with ErrResponseTooLarge.  0 removes the limit.
+func WithMaxResponseSize(bytes int64) Option {
+	return func(client *Client) {
+		client.maxResponseSize = bytes
+	}
+}
+
+// Reads a response body, failing once it exceeds the maximum response size
+func (c *Client) readBody(body io.Reader) ([]byte, error) {
+	if c.maxResponseSize <= 0 {
+		return ioutil.ReadAll(body)
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(body, c.maxResponseSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > c.maxResponseSize {
+		return nil, ErrResponseTooLarge
+	}
+
+	return data, nil
+}
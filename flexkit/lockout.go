@@ -0,0 +1,133 @@
+package flexkit
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Matches errors returned when Plasso refuses a login after too many failed
+// attempts, use errors.As with an AccountLockedError to get when to retry
+var ErrAccountLocked = errors.New("flexkit: account locked after too many login attempts")
+
+// Returned by Login, CompleteLogin and LoginWithOAuth while Plasso blocks
+// logins to the account, or from the client's address, after too many failed
+// attempts
+type AccountLockedError struct {
+	RetryAfter time.Duration // How long until logins are accepted again, 0 if Plasso did not say
+	Err        error         // The *APIError of the response
+}
+
+func (err *AccountLockedError) Error() string {
+	if err.RetryAfter <= 0 {
+		return ErrAccountLocked.Error()
+	}
+
+	return ErrAccountLocked.Error() + ", retry after " + err.RetryAfter.String()
+}
+
+func (err *AccountLockedError) Is(target error) bool {
+	return target == ErrAccountLocked
+}
+
+func (err *AccountLockedError) Unwrap() error {
+	return err.Err
+}
+
+// Returns an AccountLockedError if an error response of a login reports a
+// lockout, otherwise err.  A 429 without a lockout error code is rate limiting
+// and is left as it is.
+func lockoutError(body []byte, err error) error {
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
+		return err
+	}
+
+	var response struct {
+		Error      string `json:"error"`
+		RetryAfter int    `json:"retry_after"` // Seconds
+	}
+	json.Unmarshal(body, &response)
+
+	var locked = response.Error == "account_locked" || response.Error == "too_many_attempts"
+	if !locked && apiError.StatusCode != http.StatusLocked {
+		return err
+	}
+
+	var lockout = &AccountLockedError{RetryAfter: time.Duration(response.RetryAfter) * time.Second, Err: err}
+	if lockout.RetryAfter == 0 && apiError.RateLimit != nil {
+		lockout.RetryAfter = max(apiError.RateLimit.RetryAfter, 0)
+	}
+
+	return lockout
+}
+
+// Spaces out the calls to Login so that at most one starts every interval,
+// for tools that log in many members in a row.  After an AccountLockedError
+// further logins of the same email wait until its lockout ends.
+func WithLoginThrottle(interval time.Duration) Option {
+	return func(client *Client) {
+		client.loginThrottle = &loginThrottle{interval: interval, locked: map[string]time.Time{}}
+	}
+}
+
+// Shared by the copies WithContext makes of a client
+type loginThrottle struct {
+	mutex    sync.Mutex
+	interval time.Duration
+	next     time.Time            // When the next login may start
+	locked   map[string]time.Time // When the lockouts of emails end
+}
+
+// Waits for the turn of a login of email, returns the error of ctx if it is
+// done first.  A turn is only taken once the wait is over, so giving up does
+// not hold back other logins.
+func (c *Client) waitForLogin(email string) error {
+	var throttle = c.loginThrottle
+	if throttle == nil {
+		return nil
+	}
+
+	var ctx = c.context()
+	for {
+		throttle.mutex.Lock()
+		var now = time.Now()
+		var start = throttle.next
+		if until, ok := throttle.locked[email]; ok {
+			if !until.After(now) {
+				delete(throttle.locked, email)
+			} else if until.After(start) {
+				start = until
+			}
+		}
+		if !start.After(now) {
+			throttle.next = now.Add(throttle.interval)
+			throttle.mutex.Unlock()
+			return nil
+		}
+		throttle.mutex.Unlock()
+
+		if !sleep(ctx, start.Sub(now)) {
+			return ctx.Err()
+		}
+	}
+}
+
+// Holds back logins of email until a lockout reported by err ends
+func (c *Client) recordLogin(email string, err error) {
+	var lockout *AccountLockedError
+	if c.loginThrottle == nil || !errors.As(err, &lockout) {
+		return
+	}
+
+	var throttle = c.loginThrottle
+	throttle.mutex.Lock()
+	defer throttle.mutex.Unlock()
+
+	var until = time.Now().Add(lockout.RetryAfter)
+	if until.After(throttle.locked[email]) {
+		throttle.locked[email] = until
+	}
+}
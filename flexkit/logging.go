@@ -0,0 +1,186 @@
+package flexkit
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+)
+
+const redacted string = "[REDACTED]"
+
+// Fields of the API, by their name on the wire, that hold tokens, secrets or
+// card details
+var sensitiveFields = []string{
+	"token", "pltoken", "password", "secret_key",
+	"cc_last_4", "cc_type", "ccLast4", "ccType", "last4",
+	// Complete payment challenges
+	"client_secret",
+	// Two-factor logins
+	"code", "challenge", "two_factor_challenge", "provisioning_uri",
+	// Claim guest orders and gifts
+	"claim_token", "claim_url", "gift_code",
+	"licenseKey",
+}
+
+// Keys whose values are never logged
+var sensitiveKeys = fieldSet(sensitiveFields)
+
+func fieldSet(fields []string) map[string]bool {
+	var set = make(map[string]bool, len(fields))
+	for _, field := range fields {
+		set[field] = true
+	}
+
+	return set
+}
+
+// Returns the names of the fields of requests and responses that hold tokens,
+// secrets or card details.  The client redacts them from logs, use them to
+// scrub anything else that keeps API traffic, such as test fixtures.
+func SensitiveFields() []string {
+	return slices.Clone(sensitiveFields)
+}
+
+// Logs requests, failed responses, retries and GraphQL errors to logger.
+// Requests are logged at debug level, failures and retries at warn level.  Tokens, passwords and card
+// details are redacted from logged bodies.
+func WithLogger(logger *slog.Logger) Option {
+	return func(client *Client) {
+		client.logger = logger
+	}
+}
+
+func (c *Client) logRequest(req *http.Request) {
+	if c.logger == nil {
+		return
+	}
+
+	var body []byte
+	if req.GetBody != nil {
+		reader, err := req.GetBody()
+		if err == nil {
+			body, _ = ioutil.ReadAll(reader)
+			reader.Close()
+		}
+	}
+
+	c.logger.Debug("flexkit request",
+		"method", req.Method,
+		"url", req.URL.Redacted(),
+		"request_id", req.Header.Get(requestIDHeader),
+		"body", redact(body))
+}
+
+func (c *Client) logResponse(req *http.Request, res *http.Response, body []byte, err error) {
+	if c.logger == nil {
+		return
+	}
+
+	if err != nil {
+		c.logger.Warn("flexkit request failed",
+			"method", req.Method,
+			"url", req.URL.Redacted(),
+			"request_id", req.Header.Get(requestIDHeader),
+			"error", err)
+		return
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		c.logger.Warn("flexkit request failed",
+			"method", req.Method,
+			"url", req.URL.Redacted(),
+			"request_id", req.Header.Get(requestIDHeader),
+			"status", res.StatusCode,
+			"body", redact(body))
+		return
+	}
+
+	c.logger.Debug("flexkit response",
+		"method", req.Method,
+		"url", req.URL.Redacted(),
+		"request_id", req.Header.Get(requestIDHeader),
+		"status", res.StatusCode)
+}
+
+// Logs that a rate limited request is sent again after delay
+func (c *Client) logRetry(req *http.Request, res *http.Response, attempt int, delay time.Duration) {
+	if c.logger == nil {
+		return
+	}
+
+	c.logger.Warn("flexkit retrying rate limited request",
+		"method", req.Method,
+		"url", req.URL.Redacted(),
+		"request_id", req.Header.Get(requestIDHeader),
+		"attempt", attempt,
+		"status", res.StatusCode,
+		"delay", delay)
+}
+
+func (c *Client) logGraphQLErrors(req *http.Request, body []byte) {
+	if c.logger == nil {
+		return
+	}
+
+	var response struct {
+		Errors GraphQLErrors `json:"errors"`
+	}
+	if json.Unmarshal(body, &response) != nil || len(response.Errors) == 0 {
+		return
+	}
+
+	c.logger.Warn("flexkit graphql error",
+		"request_id", req.Header.Get(requestIDHeader),
+		"error", response.Errors.Error())
+}
+
+// Returns body with sensitive values replaced.  Bodies that are not JSON are
+// not logged at all since they cannot be redacted reliably.
+func redact(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var value interface{}
+	if json.Unmarshal(body, &value) != nil {
+		return redacted
+	}
+
+	output, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return redacted
+	}
+
+	return string(output)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if sensitiveKeys[key] {
+				v[key] = redacted
+			} else {
+				v[key] = redactValue(item)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+
+	return value
+}
+
+// Logs an entry of a response that was left out because it could not be read
+func (c *Client) logSkipped(kind string, id string, err error) {
+	if c.logger == nil {
+		return
+	}
+
+	c.logger.Warn("flexkit skipped "+kind, "id", id, "error", err)
+}
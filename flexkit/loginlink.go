@@ -0,0 +1,50 @@
+package flexkit
+
+type loginLinkRequest struct {
+	PublicKey string `json:"public_key"`
+	Email     string `json:"email"`
+}
+
+type loginLinkExchangeRequest struct {
+	PublicKey string `json:"public_key"`
+	Token     string `json:"token"`
+}
+
+// Emails the member a link that logs them in without a password.  The link
+// leads to the login page of the space with a token, pass it to
+// ExchangeLoginLinkToken.  No error is returned for unknown emails, so the
+// call cannot be used to find out who is a member.
+func (c *Client) SendLoginLink(email string) error {
+	var err = validateEmail("login link", email, true)
+	if err != nil {
+		return err
+	}
+
+	var request = loginLinkRequest{PublicKey: c.getPublicKey(), Email: email}
+
+	_, err = c.sendRequest("POST", "/api/service/login_link", request)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Logs in the member a link sent by SendLoginLink was for.  Tokens can only be
+// exchanged once and expire after a short time.
+func (c *Client) ExchangeLoginLinkToken(token string) (*Member, error) {
+	var request = loginLinkExchangeRequest{PublicKey: c.getPublicKey(), Token: token}
+
+	body, err := c.sendRequest("POST", "/api/service/login_link/exchange", request)
+	if err != nil {
+		return nil, err
+	}
+
+	var r tokenResponse
+	err = c.decode(body, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.NewMember(request.PublicKey, r.Token), nil
+}
@@ -0,0 +1,83 @@
+package flexkit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/Plasso/plasso-go/flexkit"
+	"github.com/Plasso/plasso-go/flexkit/flexkittest"
+)
+
+// Keeps the pltoken of every request to /api/services/user
+type tokenRecorder struct {
+	tokens []string
+}
+
+func (recorder *tokenRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Path == "/api/services/user" {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+
+		var fields struct {
+			Token string `json:"pltoken"`
+		}
+		err = json.Unmarshal(body, &fields)
+		if err != nil {
+			return nil, err
+		}
+		recorder.tokens = append(recorder.tokens, fields.Token)
+	}
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestMemberUpdatesSendToken(t *testing.T) {
+	var server = flexkittest.NewServer()
+	defer server.Close()
+
+	server.AddPlan("pro")
+	server.AddMember(flexkittest.Member{
+		MemberData: flexkit.MemberData{Email: "jane@example.com", Plan: "basic"},
+		Password:   "password",
+	})
+
+	var recorder = &tokenRecorder{}
+	var client = server.Client(flexkit.WithRoundTripper(recorder))
+	member, err := client.Login(flexkit.LoginRequest{PublicKey: "test", Email: "jane@example.com", Password: "password"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = member.UpdateSettings(flexkit.SettingsRequest{Name: "Jane Roe", Shipping: flexkit.Address{Country: "US"}})
+	if err != nil {
+		t.Fatalf("UpdateSettings: %v", err)
+	}
+
+	err = member.UpdateCreditCard(flexkit.CreditCardRequest{Last4: "4242", Type: "Visa", PlanId: "pro", Token: "tok_visa"})
+	if err != nil {
+		t.Fatalf("UpdateCreditCard: %v", err)
+	}
+
+	if len(recorder.tokens) != 2 {
+		t.Fatalf("got %d requests, want 2", len(recorder.tokens))
+	}
+	for _, token := range recorder.tokens {
+		if token != member.TokenValue() {
+			t.Errorf("pltoken = %q, want %q", token, member.TokenValue())
+		}
+	}
+
+	var state, _ = server.Member("jane@example.com")
+	if state.Name != "Jane Roe" || state.ShippingCountry != "US" {
+		t.Errorf("settings not applied: name %q, country %q", state.Name, state.ShippingCountry)
+	}
+	if state.Plan != "pro" || state.CreditCardLast4 != "4242" {
+		t.Errorf("card not applied: plan %q, last 4 %q", state.Plan, state.CreditCardLast4)
+	}
+}
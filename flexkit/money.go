@@ -0,0 +1,130 @@
+package flexkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// An amount of money in the minor unit of its currency, e.g. cents for USD
+type Money struct {
+	Amount   int64  `json:"amount"`   // Amount in minor units
+	Currency string `json:"currency"` // ISO 4217 currency code, e.g. "USD"
+}
+
+// Currencies whose minor unit is not a hundredth
+var currencyExponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// Returns the number of decimals of the minor unit of currency
+func currencyExponent(currency string) int {
+	if exponent, ok := currencyExponents[strings.ToUpper(currency)]; ok {
+		return exponent
+	}
+
+	return 2
+}
+
+// Parses a decimal amount such as "10.50" in the given currency
+func ParseMoney(amount string, currency string) (Money, error) {
+	var exponent = currencyExponent(currency)
+	var value = strings.TrimSpace(amount)
+	var negative = strings.HasPrefix(value, "-")
+	value = strings.TrimPrefix(value, "-")
+
+	var whole, fraction, _ = strings.Cut(value, ".")
+	if whole == "" && fraction == "" {
+		return Money{}, fmt.Errorf("flexkit: invalid amount %q", amount)
+	}
+	if len(fraction) > exponent {
+		return Money{}, fmt.Errorf("flexkit: amount %q has more than %d decimals", amount, exponent)
+	}
+	fraction += strings.Repeat("0", exponent-len(fraction))
+
+	minor, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil || strings.ContainsAny(whole+fraction, "+-") {
+		return Money{}, fmt.Errorf("flexkit: invalid amount %q", amount)
+	}
+
+	if negative {
+		minor = -minor
+	}
+
+	return Money{Amount: minor, Currency: strings.ToUpper(currency)}, nil
+}
+
+// Reports whether the amount is zero and no currency is set
+func (money Money) IsZero() bool {
+	return money == Money{}
+}
+
+// Returns the amount as a decimal string, e.g. "10.50"
+func (money Money) Decimal() string {
+	var exponent = currencyExponent(money.Currency)
+	var amount = money.Amount
+	var sign = ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	var digits = strconv.FormatInt(amount, 10)
+	if exponent == 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+}
+
+// Returns the amount and currency, e.g. "10.50 USD"
+func (money Money) String() string {
+	if money.Currency == "" {
+		return money.Decimal()
+	}
+
+	return money.Decimal() + " " + money.Currency
+}
+
+// Sends the amount as the decimal string the API expects, with its currency
+func (product Product) MarshalJSON() ([]byte, error) {
+	type plain Product
+	var amount = ""
+	if !product.Amount.IsZero() {
+		amount = product.Amount.Decimal()
+	}
+
+	return json.Marshal(struct {
+		plain
+		Amount   string `json:"amount"`
+		Currency string `json:"currency,omitempty"`
+	}{plain(product), amount, product.Amount.Currency})
+}
+
+func (product *Product) UnmarshalJSON(data []byte) error {
+	type plain Product
+	var wire struct {
+		plain
+		Amount   string `json:"amount"`
+		Currency string `json:"currency"`
+	}
+
+	var err = json.Unmarshal(data, &wire)
+	if err != nil {
+		return err
+	}
+
+	*product = Product(wire.plain)
+	if wire.Amount != "" {
+		product.Amount, err = ParseMoney(wire.Amount, wire.Currency)
+	}
+
+	return err
+}
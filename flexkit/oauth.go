@@ -0,0 +1,42 @@
+package flexkit
+
+// A social sign-in provider whose tokens Plasso accepts
+type OAuthProvider string
+
+// Supported social sign-in providers
+const (
+	OAuthGoogle OAuthProvider = "google" // An ID token from Google Sign-In
+	OAuthGitHub OAuthProvider = "github" // An OAuth access token from GitHub
+	OAuthApple  OAuthProvider = "apple"  // An identity token from Sign in with Apple
+)
+
+type oauthLoginRequest struct {
+	PublicKey string        `json:"public_key"`
+	Provider  OAuthProvider `json:"provider"`
+	Token     string        `json:"token"`
+}
+
+// Logs in the member whose email is verified by a token from a social sign-in
+// provider, so apps using social sign-in can still gate on Plasso membership.
+// Plasso checks the token with the provider, it must have been issued for the
+// app set up in the space.  Like Login, returns a TwoFactorRequiredError for
+// members with two-factor authentication enabled.
+func (c *Client) LoginWithOAuth(provider OAuthProvider, providerToken string) (*Member, error) {
+	var request = oauthLoginRequest{PublicKey: c.getPublicKey(), Provider: provider, Token: providerToken}
+
+	body, err := c.sendRequest("POST", "/api/service/login/oauth", request)
+	if err != nil {
+		return nil, lockoutError(body, err)
+	}
+
+	var r loginResponse
+	err = c.decode(body, &r)
+	if err != nil {
+		return nil, err
+	}
+	if r.Challenge != "" {
+		return nil, &TwoFactorRequiredError{Challenge: r.Challenge}
+	}
+
+	return c.NewMember(request.PublicKey, r.Token), nil
+}
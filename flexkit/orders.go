@@ -0,0 +1,205 @@
+package flexkit
+
+import (
+	"context"
+	"time"
+)
+
+// Fulfillment statuses of an order
+const (
+	FulfillmentUnfulfilled = "unfulfilled" // Not shipped yet
+	FulfillmentShipped     = "shipped"     // On its way to the customer
+	FulfillmentDelivered   = "delivered"   // Arrived at the customer
+)
+
+const listOrdersQuery string = `
+query listOrders($token: String, $first: Int, $after: String) {
+  member(token: $token) {
+    orders(first: $first, after: $after) {
+      nodes {
+        id,
+        number,
+        currency,
+        total,
+        fulfillmentStatus,
+        carrier,
+        trackingNumber,
+        trackingUrl,
+        createdAt,
+        shippingInfo {
+          name
+          address
+          city
+          state
+          zip
+          country
+        },
+        lines {
+          productId,
+          description,
+          quantity,
+          amount
+        }
+      },
+      pageInfo {
+        endCursor,
+        hasNextPage
+      }
+    }
+  }
+}`
+
+// A purchase of products by a member
+type Order struct {
+	Id                string      // Plasso order id
+	Number            string      // Order number shown to the customer
+	Total             Money       // Amount paid
+	Lines             []OrderLine // The products bought
+	Shipping          Address     // Where the order is shipped to
+	FulfillmentStatus string      // One of the Fulfillment* statuses
+	Tracking          Tracking    // Shipment tracking, empty until shipped
+	CreatedAt         time.Time   // When the order was placed
+}
+
+// A product of an order
+type OrderLine struct {
+	ProductId   string // Plasso product id
+	Description string // Name of the product
+	Quantity    int    // Number of units
+	Amount      Money  // Total of the line
+}
+
+// Tracking of a shipment
+type Tracking struct {
+	Carrier string // Shipping carrier, e.g. "UPS"
+	Number  string // Tracking number
+	URL     string // Page to track the shipment on (optional)
+}
+
+type orderNode struct {
+	Id                string    `json:"id"`
+	Number            string    `json:"number"`
+	Currency          string    `json:"currency"`
+	Total             string    `json:"total"`
+	FulfillmentStatus string    `json:"fulfillmentStatus"`
+	Carrier           string    `json:"carrier"`
+	TrackingNumber    string    `json:"trackingNumber"`
+	TrackingUrl       string    `json:"trackingUrl"`
+	CreatedAt         time.Time `json:"createdAt"`
+	ShippingInfo      struct {
+		Name    string `json:"name"`
+		Address string `json:"address"`
+		City    string `json:"city"`
+		State   string `json:"state"`
+		Zip     string `json:"zip"`
+		Country string `json:"country"`
+	} `json:"shippingInfo"`
+	Lines []struct {
+		ProductId   string `json:"productId"`
+		Description string `json:"description"`
+		Quantity    int    `json:"quantity"`
+		Amount      string `json:"amount"`
+	} `json:"lines"`
+}
+
+type fulfillmentRequest struct {
+	Id             string `json:"id"`
+	Status         string `json:"status"`
+	Carrier        string `json:"carrier"`
+	TrackingNumber string `json:"tracking_number"`
+	TrackingURL    string `json:"tracking_url"`
+}
+
+// Lists the member's orders, newest first.  Orders and lines whose amounts
+// cannot be read are left out and logged with WithLogger.
+func (member *Member) ListOrders() *Pager[Order] {
+	return newPager(member.context(), "", func(ctx context.Context, cursor string) ([]Order, string, bool, error) {
+		var response struct {
+			Member struct {
+				Orders struct {
+					Nodes    []orderNode `json:"nodes"`
+					PageInfo pageInfo    `json:"pageInfo"`
+				} `json:"orders"`
+			} `json:"member"`
+		}
+		var variables = map[string]interface{}{
+			"token": member.TokenValue(),
+			"first": defaultPageSize,
+			"after": cursor,
+		}
+
+		var err = member.getClient().GraphQL(ctx, listOrdersQuery, variables, &response)
+		if err != nil {
+			return nil, "", false, err
+		}
+
+		var client = member.getClient()
+		var orders = make([]Order, 0, len(response.Member.Orders.Nodes))
+		for _, node := range response.Member.Orders.Nodes {
+			order, err := node.order(client)
+			if err != nil {
+				client.logSkipped("order", node.Id, err)
+				continue
+			}
+			orders = append(orders, order)
+		}
+
+		var page = response.Member.Orders.PageInfo
+		return orders, page.EndCursor, page.HasNextPage, nil
+	})
+}
+
+// Converts node, leaving out lines whose amount cannot be read
+func (node *orderNode) order(c *Client) (Order, error) {
+	var order = Order{
+		Id:                node.Id,
+		Number:            node.Number,
+		FulfillmentStatus: node.FulfillmentStatus,
+		Tracking:          Tracking{Carrier: node.Carrier, Number: node.TrackingNumber, URL: node.TrackingUrl},
+		CreatedAt:         node.CreatedAt,
+		Shipping: Address{
+			Name:    node.ShippingInfo.Name,
+			Street:  node.ShippingInfo.Address,
+			City:    node.ShippingInfo.City,
+			State:   node.ShippingInfo.State,
+			Zip:     node.ShippingInfo.Zip,
+			Country: node.ShippingInfo.Country,
+		},
+	}
+
+	var err = parseAmount(node.Total, node.Currency, &order.Total)
+	if err != nil {
+		return Order{}, err
+	}
+
+	for _, lineNode := range node.Lines {
+		var line = OrderLine{ProductId: lineNode.ProductId, Description: lineNode.Description, Quantity: lineNode.Quantity}
+		err = parseAmount(lineNode.Amount, node.Currency, &line.Amount)
+		if err != nil {
+			c.logSkipped("order line", lineNode.ProductId, err)
+			continue
+		}
+		order.Lines = append(order.Lines, line)
+	}
+
+	return order, nil
+}
+
+// Marks an order as shipped with the given tracking.  The member sees the
+// tracking in ListOrders.
+func (space *SpaceClient) UpdateFulfillment(orderID string, tracking Tracking) error {
+	var request = fulfillmentRequest{
+		Id:             orderID,
+		Status:         FulfillmentShipped,
+		Carrier:        tracking.Carrier,
+		TrackingNumber: tracking.Number,
+		TrackingURL:    tracking.URL,
+	}
+
+	_, err := space.client.sendRequest("POST", "/api/space/orders?action=fulfill", request)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package flexkit
+
+import (
+	"context"
+)
+
+// Fetches the page after cursor.  Returns the items, the cursor of the next
+// page and whether there are more pages.
+type pageFetcher[T any] func(ctx context.Context, cursor string) ([]T, string, bool, error)
+
+// Iterates over a paginated list, fetching pages as needed.  All list calls
+// return a Pager.
+//
+//	var members = space.ListMembers(flexkit.ListMembersOptions{})
+//	for members.Next() {
+//		var member = members.Item()
+//		...
+//	}
+//	if members.Err() != nil {
+//		...
+//	}
+type Pager[T any] struct {
+	ctx     context.Context // Used by Next, the context of the client that made the pager
+	fetch   pageFetcher[T]
+	page    []T
+	current T
+	cursor  string
+	more    bool
+	err     error
+}
+
+func newPager[T any](ctx context.Context, cursor string, fetch pageFetcher[T]) *Pager[T] {
+	return &Pager[T]{ctx: ctx, fetch: fetch, cursor: cursor, more: true}
+}
+
+// Advances to the next item.  Returns false when there are no more items or
+// an error occurred, check Err to tell them apart.  Pages are fetched with the
+// context of the client the list call was made on, see Client.WithContext.
+func (pager *Pager[T]) Next() bool {
+	return pager.next(pager.ctx)
+}
+
+// Returns the item Next advanced to
+func (pager *Pager[T]) Item() T {
+	return pager.current
+}
+
+// Returns the error that stopped the iteration, if any
+func (pager *Pager[T]) Err() error {
+	return pager.err
+}
+
+// Returns a cursor that continues the listing after the last fetched page
+func (pager *Pager[T]) Cursor() string {
+	return pager.cursor
+}
+
+// Fetches all remaining items
+func (pager *Pager[T]) All(ctx context.Context) ([]T, error) {
+	var items []T
+	for pager.next(ctx) {
+		items = append(items, pager.current)
+	}
+
+	return items, pager.err
+}
+
+func (pager *Pager[T]) next(ctx context.Context) bool {
+	if pager.err != nil {
+		return false
+	}
+
+	for len(pager.page) == 0 && pager.more {
+		var items, cursor, more, err = pager.fetch(ctx, pager.cursor)
+		if err != nil {
+			pager.err = err
+			return false
+		}
+
+		pager.page = items
+		pager.cursor = cursor
+		pager.more = more && len(items) > 0
+	}
+
+	if len(pager.page) == 0 {
+		return false
+	}
+
+	pager.current = pager.page[0]
+	pager.page = pager.page[1:]
+	return true
+}
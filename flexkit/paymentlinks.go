@@ -0,0 +1,60 @@
+package flexkit
+
+import (
+	"errors"
+	"time"
+)
+
+// Options for CreatePaymentLink
+type PaymentLinkOptions struct {
+	SingleUse bool      // Stops accepting payment after the first one
+	ExpiresAt time.Time // When the link stops accepting payment, zero for never
+	Coupon    string    // Coupon code applied to the payment (optional)
+}
+
+// A shareable link to pay for products
+type PaymentLink struct {
+	Id        string    `json:"id"`         // Plasso payment link id
+	URL       string    `json:"url"`        // Page the customer pays on
+	SingleUse bool      `json:"single_use"` // Whether the link accepts only one payment
+	ExpiresAt time.Time `json:"expires_at"` // When the link stops accepting payment, zero for never
+}
+
+type paymentLinkRequest struct {
+	PublicKey string     `json:"public_key"`
+	Products  []Product  `json:"products"`
+	SingleUse bool       `json:"single_use"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Coupon    string     `json:"coupon,omitempty"`
+}
+
+// Creates a link to pay for products in the space set with WithPublicKey,
+// e.g. to send a customer over email or chat
+func (c *Client) CreatePaymentLink(products []Product, options PaymentLinkOptions) (*PaymentLink, error) {
+	if len(products) == 0 {
+		return nil, errors.New("flexkit: payment link needs products")
+	}
+
+	var request = paymentLinkRequest{
+		PublicKey: c.getPublicKey(),
+		Products:  products,
+		SingleUse: options.SingleUse,
+		Coupon:    options.Coupon,
+	}
+	if !options.ExpiresAt.IsZero() {
+		request.ExpiresAt = &options.ExpiresAt
+	}
+
+	body, err := c.sendRequest("POST", "/api/payment_links", request)
+	if err != nil {
+		return nil, err
+	}
+
+	var link PaymentLink
+	err = c.decode(body, &link)
+	if err != nil {
+		return nil, err
+	}
+
+	return &link, nil
+}
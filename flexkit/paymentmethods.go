@@ -0,0 +1,93 @@
+package flexkit
+
+const listPaymentMethodsQuery string = `
+query listPaymentMethods($token: String) {
+  member(token: $token) {
+    paymentMethods {
+      id,
+      type,
+      last4,
+      expMonth,
+      expYear,
+      isDefault
+    }
+  }
+}`
+
+// A card saved for a member
+type PaymentMethod struct {
+	Id       string `json:"id"`        // Id of the payment method
+	Type     string `json:"type"`      // Informational, type of card
+	Last4    string `json:"last4"`     // Informational, last 4 of credit card
+	ExpMonth int    `json:"expMonth"`  // Expiry month, 1 to 12
+	ExpYear  int    `json:"expYear"`   // Expiry year, e.g. 2030
+	Default  bool   `json:"isDefault"` // Whether subscriptions are charged to this method
+}
+
+type paymentMethodRequest struct {
+	Id    string `json:"id,omitempty"`
+	Token string `json:"token,omitempty"`
+
+	MemberToken string `json:"pltoken"`
+}
+
+// Lists the payment methods saved for the member
+func (member *Member) ListPaymentMethods() ([]PaymentMethod, error) {
+	var response struct {
+		Member struct {
+			PaymentMethods []PaymentMethod `json:"paymentMethods"`
+		} `json:"member"`
+	}
+	var variables = map[string]interface{}{"token": member.TokenValue()}
+
+	var err = member.getClient().GraphQL(member.context(), listPaymentMethodsQuery, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Member.PaymentMethods, nil
+}
+
+// Saves a new payment method for the member.  Unlike UpdateCreditCard this keeps
+// the existing methods, use SetDefaultPaymentMethod to charge the new one.
+func (member *Member) AddPaymentMethod(token string) (*PaymentMethod, error) {
+	var request = paymentMethodRequest{Token: token, MemberToken: member.TokenValue()}
+
+	var client = member.getClient()
+	body, err := client.sendRequest("POST", "/api/services/user?action=add_payment_method", request)
+	if err != nil {
+		return nil, err
+	}
+
+	var method PaymentMethod
+	err = client.decode(body, &method)
+	if err != nil {
+		return nil, err
+	}
+
+	return &method, nil
+}
+
+// Makes the payment method the one subscriptions are charged to
+func (member *Member) SetDefaultPaymentMethod(id string) error {
+	var request = paymentMethodRequest{Id: id, MemberToken: member.TokenValue()}
+
+	_, err := member.getClient().sendRequest("POST", "/api/services/user?action=default_payment_method", request)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Removes a saved payment method
+func (member *Member) DeletePaymentMethod(id string) error {
+	var request = paymentMethodRequest{Id: id, MemberToken: member.TokenValue()}
+
+	_, err := member.getClient().sendRequest("POST", "/api/services/user?action=delete_payment_method", request)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package flexkit
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Returned when no receipt exists for a payment
+var ErrReceiptNotFound = errors.New("flexkit: receipt not found")
+
+const getReceiptQuery string = `
+query getReceipt($publicKey: String, $paymentId: String) {
+  receipt(publicKey: $publicKey, paymentId: $paymentId) {
+    url,
+    email
+  }
+}`
+
+// Statuses of a PaymentIntent
+const (
+	PaymentRequiresAction  = "requires_action"  // The customer must complete an authentication challenge, see NextAction
+	PaymentRequiresCapture = "requires_capture" // Authorized, waiting for Capture or Void
+	PaymentSucceeded       = "succeeded"        // The customer was charged
+	PaymentCanceled        = "canceled"         // Voided, the customer was not charged
+)
+
+// A payment that can be completed later.  Authorized payments hold the amount
+// on the customer's card until they are captured or voided.
+type PaymentIntent struct {
+	Id         string         // Plasso payment id
+	Status     string         // One of the Payment* statuses
+	Amount     Money          // Total amount of the payment
+	NextAction *PaymentAction // What the customer has to do when Status is PaymentRequiresAction
+
+	publicKey string
+	client    *Client
+}
+
+// An authentication challenge, such as 3-D Secure, the customer must complete
+// before a payment goes through.  Either send the customer to RedirectURL or
+// pass ClientSecret to the javascript flexkit to show the challenge, then call
+// Confirm on the intent.
+type PaymentAction struct {
+	RedirectURL  string `json:"redirect_url"`  // Page the customer completes the challenge on
+	ClientSecret string `json:"client_secret"` // Secret for completing the challenge in the browser
+}
+
+// Returned by CreatePayment when the customer has to complete an
+// authentication challenge before they are charged.
+type ActionRequiredError struct {
+	Intent *PaymentIntent
+}
+
+func (err *ActionRequiredError) Error() string {
+	return fmt.Sprintf("flexkit: payment %s requires customer action", err.Intent.Id)
+}
+
+type paymentIntentResponse struct {
+	Id         string         `json:"id"`
+	Status     string         `json:"status"`
+	Amount     string         `json:"amount"`
+	Currency   string         `json:"currency"`
+	NextAction *PaymentAction `json:"next_action"`
+}
+
+type paymentIntentRequest struct {
+	Id        string `json:"id"`
+	PublicKey string `json:"public_key"`
+}
+
+// Authorizes a payment without charging the customer.  Call Capture on the
+// returned intent to charge them, or Void to release the hold.
+func (c *Client) AuthorizePayment(request PaymentRequest) (*PaymentIntent, error) {
+	request.PublicKey = c.requestPublicKey(request.PublicKey)
+	body, err := c.send(c.context(), "POST", "/api/payments?action=authorize", c.idempotencyHeader(request.IdempotencyKey), request)
+	if err != nil {
+		return nil, stockError(body, err)
+	}
+
+	var intent = &PaymentIntent{publicKey: request.PublicKey, client: c}
+	err = intent.update(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return intent, nil
+}
+
+// Completes a payment of the space set with WithPublicKey once the customer
+// finished the challenge of an ActionRequiredError, for when only the id of
+// the intent was kept.  Check the Status of the returned intent, the challenge
+// may have failed.
+func (c *Client) ConfirmPayment(intentID string) (*PaymentIntent, error) {
+	var intent = &PaymentIntent{Id: intentID, publicKey: c.getPublicKey(), client: c}
+
+	var err = intent.send("confirm")
+	if err != nil {
+		return nil, err
+	}
+
+	return intent, nil
+}
+
+// Completes the payment once the customer finished the challenge of an
+// ActionRequiredError, with the public key the payment was made with.  Check
+// Status afterwards, the challenge may have failed.
+func (intent *PaymentIntent) Confirm() error {
+	return intent.send("confirm")
+}
+
+// Charges the customer for an authorized payment
+func (intent *PaymentIntent) Capture() error {
+	return intent.send("capture")
+}
+
+// Releases an authorized payment without charging the customer
+func (intent *PaymentIntent) Void() error {
+	return intent.send("void")
+}
+
+func (intent *PaymentIntent) send(action string) error {
+	var request = paymentIntentRequest{Id: intent.Id, PublicKey: intent.publicKey}
+
+	body, err := intent.client.sendRequest("POST", "/api/payments?action="+action, request)
+	if err != nil {
+		return err
+	}
+
+	return intent.update(body)
+}
+
+// Updates the intent from a payment response
+func (intent *PaymentIntent) update(body []byte) error {
+	var response paymentIntentResponse
+	var err = intent.client.decode(body, &response)
+	if err != nil {
+		return err
+	}
+
+	return intent.set(response)
+}
+
+// Updates the intent from a decoded payment response
+func (intent *PaymentIntent) set(response paymentIntentResponse) error {
+	var err error
+	intent.Id = response.Id
+	intent.Status = response.Status
+	intent.NextAction = response.NextAction
+	if response.Amount != "" {
+		intent.Amount, err = ParseMoney(response.Amount, response.Currency)
+	}
+
+	return err
+}
+
+// The receipt of a payment
+type Receipt struct {
+	PaymentId string `json:"-"`     // Plasso payment id
+	URL       string `json:"url"`   // Hosted receipt page
+	Email     string `json:"email"` // Where the receipt was sent
+}
+
+type resendReceiptRequest struct {
+	Id        string `json:"id"`
+	PublicKey string `json:"public_key"`
+	Email     string `json:"email,omitempty"`
+}
+
+// Get the receipt of a payment in the space set with WithPublicKey
+func (c *Client) GetReceipt(paymentID string) (*Receipt, error) {
+	var response struct {
+		Receipt *Receipt `json:"receipt"`
+	}
+	var variables = map[string]interface{}{"publicKey": c.getPublicKey(), "paymentId": paymentID}
+
+	var err = c.GraphQL(c.context(), getReceiptQuery, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Receipt == nil {
+		return nil, ErrReceiptNotFound
+	}
+
+	response.Receipt.PaymentId = paymentID
+	return response.Receipt, nil
+}
+
+// Sends the receipt of a payment again.  An empty email sends it to the
+// address the payment was made with.
+func (c *Client) ResendReceipt(paymentID string, email string) error {
+	var request = resendReceiptRequest{Id: paymentID, PublicKey: c.getPublicKey(), Email: email}
+
+	_, err := c.sendRequest("POST", "/api/payments?action=resend_receipt", request)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type refundRequest struct {
+	Id       string `json:"id"`
+	Amount   string `json:"amount,omitempty"`
+	Currency string `json:"currency,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// Refunds a payment.  A zero amount refunds it in full, otherwise only amount
+// is refunded.  The reason is shown to the customer on their receipt
+// (optional).
+func (space *SpaceClient) RefundPayment(paymentID string, amount Money, reason string) error {
+	var request = refundRequest{Id: paymentID, Reason: reason}
+	if !amount.IsZero() {
+		request.Amount = amount.Decimal()
+		request.Currency = amount.Currency
+	}
+
+	_, err := space.client.sendRequest("POST", "/api/space/payments?action=refund", request)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package flexkit
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Longest wait for a rate limit to reset before a call gives up
+const maxRateLimitWait = time.Minute
+
+// The rate limit state the API reported with a response
+type RateLimitInfo struct {
+	Limit      int           // Requests allowed per window, from X-RateLimit-Limit
+	Remaining  int           // Requests left in the window, from X-RateLimit-Remaining
+	Reset      time.Time     // When the window resets, from X-RateLimit-Reset
+	RetryAfter time.Duration // How long to wait before retrying, from Retry-After
+}
+
+// Retries calls that were rate limited with a 429 up to retries times, waiting
+// as long as the API asks to.  Calls are not retried when the wait would be
+// longer than a minute.
+func WithRateLimitRetries(retries int) Option {
+	return func(client *Client) {
+		client.rateLimitRetries = retries
+	}
+}
+
+// Parses the rate limit headers, returns nil if there are none
+func parseRateLimit(header http.Header) *RateLimitInfo {
+	var info RateLimitInfo
+	var found = false
+
+	if value, err := strconv.Atoi(header.Get("X-RateLimit-Limit")); err == nil {
+		info.Limit = value
+		found = true
+	}
+	if value, err := strconv.Atoi(header.Get("X-RateLimit-Remaining")); err == nil {
+		info.Remaining = value
+		found = true
+	}
+	if value, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		info.Reset = time.Unix(value, 0)
+		found = true
+	}
+
+	var retryAfter = header.Get("Retry-After")
+	if seconds, err := strconv.Atoi(retryAfter); err == nil {
+		info.RetryAfter = time.Duration(seconds) * time.Second
+		found = true
+	} else if date, err := http.ParseTime(retryAfter); err == nil {
+		info.RetryAfter = time.Until(date)
+		found = true
+	}
+
+	if !found {
+		return nil
+	}
+
+	return &info
+}
+
+// How long to wait before retry number attempt of a rate limited response
+func rateLimitWait(header http.Header, attempt int) time.Duration {
+	var info = parseRateLimit(header)
+	if info != nil && header.Get("Retry-After") != "" {
+		return max(info.RetryAfter, 0)
+	}
+	if info != nil && !info.Reset.IsZero() {
+		return max(time.Until(info.Reset), 0)
+	}
+
+	return time.Second << attempt
+}
+
+// Sleeps for delay, returns false if ctx is done first
+func sleep(ctx context.Context, delay time.Duration) bool {
+	var timer = time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
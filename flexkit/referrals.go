@@ -0,0 +1,38 @@
+package flexkit
+
+const getReferralQuery string = `
+query getReferral($token: String) {
+  member(token: $token) {
+    referral {
+      code,
+      url,
+      signups
+    }
+  }
+}`
+
+// The referral code of a member.  Customers who sign up or pay with the code
+// in SubscriptionRequest.Referrer or PaymentRequest.Referrer are attributed to
+// the member.
+type Referral struct {
+	Code    string `json:"code"`    // Code to pass as Referrer
+	URL     string `json:"url"`     // Page of the space that applies the code
+	Signups int    `json:"signups"` // Number of customers attributed to the member
+}
+
+// Get the referral code of the member, creating one the first time
+func (member *Member) GetReferralCode() (*Referral, error) {
+	var response struct {
+		Member struct {
+			Referral Referral `json:"referral"`
+		} `json:"member"`
+	}
+	var variables = map[string]interface{}{"token": member.TokenValue()}
+
+	var err = member.getClient().GraphQL(member.context(), getReferralQuery, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Member.Referral, nil
+}
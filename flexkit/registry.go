@@ -0,0 +1,155 @@
+package flexkit
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Returned when a registry has no space for a key or host
+var ErrUnknownSpace = errors.New("flexkit: unknown space")
+
+type registryKey struct{}
+
+// Clients for several spaces served from one codebase, keyed by public key or
+// slug.  It is safe for concurrent use.
+//
+//	var registry = flexkit.NewRegistry(flexkit.WithLogger(logger))
+//	registry.Register("acme", flexkit.FileCredentials("keys/acme.json"))
+//	registry.AddHost("shop.acme.com", "acme")
+//	http.ListenAndServe(":8080", registry.Middleware(handler))
+type Registry struct {
+	options []Option
+	mutex   sync.RWMutex
+	spaces  map[string]*registeredSpace // keyed by public key or slug
+	hosts   map[string]string           // space keys keyed by host
+}
+
+type registeredSpace struct {
+	client *Client
+	space  *SpaceClient
+}
+
+// Creates an empty registry.  The options are applied to the clients of every
+// space.
+func NewRegistry(options ...Option) *Registry {
+	return &Registry{
+		options: options,
+		spaces:  map[string]*registeredSpace{},
+		hosts:   map[string]string{},
+	}
+}
+
+// Adds the space with the given key, replacing an earlier one with the same
+// key.  The clients of the space authenticate with credentials, options are
+// applied after the options of the registry.
+func (registry *Registry) Register(key string, credentials CredentialsProvider, options ...Option) {
+	var all = append([]Option{}, registry.options...)
+	all = append(all, WithCredentials(credentials))
+	all = append(all, options...)
+
+	var space = &registeredSpace{
+		client: NewClient(all...),
+		space:  NewSpaceClient("", all...),
+	}
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	registry.spaces[key] = space
+}
+
+// Serves the space with the given key on host, e.g. "shop.acme.com"
+func (registry *Registry) AddHost(host string, key string) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	registry.hosts[strings.ToLower(host)] = key
+}
+
+// Returns the client of the space with the given key
+func (registry *Registry) Client(key string) (*Client, error) {
+	var space, err = registry.get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return space.client, nil
+}
+
+// Returns the space owner client of the space with the given key
+func (registry *Registry) SpaceClient(key string) (*SpaceClient, error) {
+	var space, err = registry.get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return space.space, nil
+}
+
+func (registry *Registry) get(key string) (*registeredSpace, error) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	var space, ok = registry.spaces[key]
+	if !ok {
+		return nil, ErrUnknownSpace
+	}
+
+	return space, nil
+}
+
+// Returns the space served on host, ignoring a port
+func (registry *Registry) forHost(host string) (*registeredSpace, error) {
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		host = name
+	}
+
+	registry.mutex.RLock()
+	var key, ok = registry.hosts[strings.ToLower(host)]
+	registry.mutex.RUnlock()
+	if !ok {
+		return nil, ErrUnknownSpace
+	}
+
+	return registry.get(key)
+}
+
+// Selects the space from the Host header of each request and makes its clients
+// available to next through ClientFromContext and SpaceClientFromContext.
+// Requests for unknown hosts get a 404.
+func (registry *Registry) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var space, err = registry.forHost(r.Host)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), registryKey{}, space)))
+	})
+}
+
+// Returns the client of the space selected by Registry.Middleware, bound to ctx
+func ClientFromContext(ctx context.Context) (*Client, bool) {
+	var space, ok = ctx.Value(registryKey{}).(*registeredSpace)
+	if !ok {
+		return nil, false
+	}
+
+	return space.client.WithContext(ctx), true
+}
+
+// Returns the space owner client of the space selected by Registry.Middleware,
+// bound to ctx
+func SpaceClientFromContext(ctx context.Context) (*SpaceClient, bool) {
+	var space, ok = ctx.Value(registryKey{}).(*registeredSpace)
+	if !ok {
+		return nil, false
+	}
+
+	return space.space.WithContext(ctx), true
+}
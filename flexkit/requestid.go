@@ -0,0 +1,31 @@
+package flexkit
+
+import (
+	"context"
+	"net/http"
+)
+
+const requestIDHeader string = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// Returns a context that makes calls send id as their X-Request-ID, e.g. the
+// id of the incoming request being handled.  Without one every call gets a
+// new id.  The id is logged and returned in APIError, so a failed call can be
+// traced with Plasso support.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// Sets the X-Request-ID of req unless the client or context headers already did
+func setRequestID(req *http.Request) {
+	if req.Header.Get(requestIDHeader) != "" {
+		return
+	}
+
+	var id, _ = req.Context().Value(requestIDKey{}).(string)
+	if id == "" {
+		id = newIdempotencyKey()
+	}
+	req.Header.Set(requestIDHeader, id)
+}
@@ -0,0 +1,38 @@
+package flexkit
+
+import (
+	"context"
+	"net/http"
+)
+
+type responseKey struct{}
+
+// The raw HTTP response of a call, for inspecting fields this package does not
+// model yet or logging exactly what the server sent
+type Response struct {
+	StatusCode int         // HTTP status code
+	Header     http.Header // Response headers
+	Body       []byte      // Response body as received
+}
+
+// Returns a context that makes calls store their response in response.  When
+// a call is retried response holds the last attempt.  Use it with WithContext
+// or calls that take a context.
+//
+//	var response flexkit.Response
+//	var ctx = flexkit.ContextWithResponse(r.Context(), &response)
+//	data, err := member.WithContext(ctx).GetData()
+//	log.Printf("%d %s", response.StatusCode, response.Body)
+func ContextWithResponse(ctx context.Context, response *Response) context.Context {
+	return context.WithValue(ctx, responseKey{}, response)
+}
+
+// Stores res in the Response of the request context, if there is one
+func recordResponse(req *http.Request, res *http.Response, body []byte) {
+	var response, ok = req.Context().Value(responseKey{}).(*Response)
+	if !ok || response == nil {
+		return
+	}
+
+	*response = Response{StatusCode: res.StatusCode, Header: res.Header.Clone(), Body: body}
+}
@@ -0,0 +1,56 @@
+package flexkit
+
+import "time"
+
+const listSessionsQuery string = `
+query listSessions($token: String) {
+  member(token: $token) {
+    sessions {
+      id,
+      device,
+      ip,
+      lastSeenAt,
+      current
+    }
+  }
+}`
+
+// A place the member is logged in
+type Session struct {
+	Id       string    `json:"id"`         // Id of the session
+	Device   string    `json:"device"`     // Informational, browser or app the member logged in with
+	IP       string    `json:"ip"`         // Address the session was last used from
+	LastSeen time.Time `json:"lastSeenAt"` // When the session was last used
+	Current  bool      `json:"current"`    // Whether this is the session of the member token
+}
+
+// Lists the sessions the member is logged in with, so they can spot logins
+// they do not recognize
+func (member *Member) ListSessions() ([]Session, error) {
+	var response struct {
+		Member struct {
+			Sessions []Session `json:"sessions"`
+		} `json:"member"`
+	}
+	var variables = map[string]interface{}{"token": member.TokenValue()}
+
+	var err = member.getClient().GraphQL(member.context(), listSessionsQuery, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Member.Sessions, nil
+}
+
+// Logs out every session of the member, including this one.  The member
+// object cannot be used after this call and must be recreated.
+func (member *Member) LogoutAllSessions() error {
+	var request = map[string]string{"pltoken": member.TokenValue()}
+
+	_, err := member.getClient().sendRequest("POST", "/api/services/user?action=logout_all", request)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package flexkit
+
+type shippingRatesRequest struct {
+	PublicKey string    `json:"public_key"`
+	Products  []Product `json:"products"`
+	shippingFields
+}
+
+type shippingRatesResponse struct {
+	Currency string `json:"currency"`
+	Options  []struct {
+		Id     string `json:"id"`
+		Name   string `json:"name"`
+		Amount string `json:"amount"`
+	} `json:"options"`
+}
+
+// Get the shipping options available for sending products to address, priced
+// for that address.  Pass the Id of the chosen option as ShippingOptions of
+// the payment.
+func (c *Client) GetShippingOptions(address Address, products []Product) ([]ShippingOption, error) {
+	var request = shippingRatesRequest{
+		PublicKey:      c.getPublicKey(),
+		Products:       products,
+		shippingFields: address.shippingFields(),
+	}
+
+	body, err := c.sendRequest("POST", "/api/shipping/rates", request)
+	if err != nil {
+		return nil, err
+	}
+
+	var response shippingRatesResponse
+	err = c.decode(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	var options = make([]ShippingOption, len(response.Options))
+	for i, option := range response.Options {
+		options[i] = ShippingOption{Id: option.Id, Name: option.Name}
+		err = parseAmount(option.Amount, response.Currency, &options[i].Amount)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return options, nil
+}
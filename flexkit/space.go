@@ -0,0 +1,279 @@
+package flexkit
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Returned when a member lookup does not match any member
+var ErrMemberNotFound = errors.New("flexkit: member not found")
+
+// Returned when no space has the configured public key
+var ErrSpaceNotFound = errors.New("flexkit: space not found")
+
+// The member fields fetched by space owner queries
+const memberSelection string = `
+    id,
+    name,
+    email,
+    ccType,
+    ccLast4,
+    shippingInfo {
+      name
+      address
+      city
+      state
+      zip
+      country
+    },
+    dataFields {
+      id,
+      label,
+      value
+    },
+    plan {
+      alias
+    },
+    trialEnd,
+    status,
+    currentPeriodEnd,
+    cancelAtPeriodEnd,
+    createdAt,
+    paymentFailedAt,
+    nextPaymentRetry,
+    quantity`
+
+const findMemberByEmailQuery string = `
+query findMemberByEmail($email: String) {
+  memberByEmail(email: $email) {` + memberSelection + `
+  }
+}`
+
+const getMemberByIdQuery string = `
+query getMemberById($id: String) {
+  memberById(id: $id) {` + memberSelection + `
+  }
+}`
+
+// A client for space owner operations, authenticated with the secret key of
+// the space.  The secret key grants access to every member, only use it from
+// your own servers.
+type SpaceClient struct {
+	client *Client
+}
+
+// Creates a client for space owner operations.  The options are the same as
+// for NewClient.  With an empty secretKey the secret key of the provider set
+// with WithCredentials is used.
+func NewSpaceClient(secretKey string, options ...Option) *SpaceClient {
+	var client = NewClient(options...)
+	client.spaceAuth = true
+	client.secretKey = secretKey
+
+	return &SpaceClient{client}
+}
+
+// Looks up a member by the email they signed up with.  Returns
+// ErrMemberNotFound if there is no such member.
+func (space *SpaceClient) FindMemberByEmail(email string) (*MemberData, error) {
+	var response struct {
+		Member *memberFields `json:"memberByEmail"`
+	}
+	var variables = map[string]interface{}{"email": email}
+
+	var err = space.client.GraphQL(space.client.context(), findMemberByEmailQuery, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return memberOrNotFound(response.Member)
+}
+
+// Looks up a member by id.  Returns ErrMemberNotFound if there is no such
+// member.
+func (space *SpaceClient) GetMemberByID(id string) (*MemberData, error) {
+	var response struct {
+		Member *memberFields `json:"memberById"`
+	}
+	var variables = map[string]interface{}{"id": id}
+
+	var err = space.client.GraphQL(space.client.context(), getMemberByIdQuery, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return memberOrNotFound(response.Member)
+}
+
+func memberOrNotFound(fields *memberFields) (*MemberData, error) {
+	if fields == nil {
+		return nil, ErrMemberNotFound
+	}
+
+	var memberData MemberData
+	fields.copyTo(&memberData)
+
+	return &memberData, nil
+}
+
+const listMembersQuery string = `
+query listMembers($first: Int, $after: String, $plan: String, $status: String) {
+  members(first: $first, after: $after, plan: $plan, status: $status) {
+    nodes {
+      id,
+      name,
+      email,
+      status,
+      createdAt,
+      plan {
+        alias
+      }
+    },
+    pageInfo {
+      endCursor,
+      hasNextPage
+    }
+  }
+}`
+
+const defaultPageSize int = 100
+
+// A member as listed by ListMembers
+type MemberSummary struct {
+	Id        string    // A unique id identifying the user, does not change
+	Email     string    // Email customer provided
+	Name      string    // Name of customer
+	Plan      string    // Plan ID
+	Status    string    // Status of the membership, e.g. "active"
+	CreatedAt time.Time // When the member signed up
+}
+
+// Options for ListMembers, all are optional
+type ListMembersOptions struct {
+	PageSize int    // Members fetched per request, defaults to 100
+	Plan     string // Only list members of this plan
+	Status   string // Only list members with this status
+	Cursor   string // Continue a previous listing from this cursor
+}
+
+type memberNode struct {
+	Id        string    `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+	Plan      struct {
+		Alias string `json:"alias"`
+	} `json:"plan"`
+	DataFields []DataItem `json:"dataFields"`
+}
+
+type listMembersResponse struct {
+	Members struct {
+		Nodes    []memberNode `json:"nodes"`
+		PageInfo pageInfo     `json:"pageInfo"`
+	} `json:"members"`
+}
+
+// Lists all members of the space.  Nothing is fetched until the pager is used.
+func (space *SpaceClient) ListMembers(options ListMembersOptions) *Pager[MemberSummary] {
+	var fetch = space.fetchMembers(listMembersQuery, options)
+
+	return newPager(space.client.context(), options.Cursor, func(ctx context.Context, cursor string) ([]MemberSummary, string, bool, error) {
+		var nodes, next, more, err = fetch(ctx, cursor)
+		if err != nil {
+			return nil, "", false, err
+		}
+
+		var members = make([]MemberSummary, len(nodes))
+		for i, node := range nodes {
+			members[i] = node.summary()
+		}
+
+		return members, next, more, nil
+	})
+}
+
+// Returns a fetcher for pages of a members query
+func (space *SpaceClient) fetchMembers(query string, options ListMembersOptions) pageFetcher[memberNode] {
+	if options.PageSize <= 0 {
+		options.PageSize = defaultPageSize
+	}
+
+	return func(ctx context.Context, cursor string) ([]memberNode, string, bool, error) {
+		var response listMembersResponse
+		var variables = map[string]interface{}{
+			"first":  options.PageSize,
+			"after":  cursor,
+			"plan":   options.Plan,
+			"status": options.Status,
+		}
+
+		var err = space.client.GraphQL(ctx, query, variables, &response)
+		if err != nil {
+			return nil, "", false, err
+		}
+
+		var page = response.Members.PageInfo
+		return response.Members.Nodes, page.EndCursor, page.HasNextPage, nil
+	}
+}
+
+func (node *memberNode) summary() MemberSummary {
+	return MemberSummary{
+		Id:        node.Id,
+		Email:     node.Email,
+		Name:      node.Name,
+		Plan:      node.Plan.Alias,
+		Status:    node.Status,
+		CreatedAt: node.CreatedAt,
+	}
+}
+
+// Suspends a member.  A suspended member cannot log in but keeps their billing
+// history, unlike Member.Delete.
+func (space *SpaceClient) SuspendMember(id string) error {
+	var request = map[string]string{"id": id}
+
+	_, err := space.client.sendRequest("POST", "/api/space/members?action=suspend", request)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Lifts the suspension of a member
+func (space *SpaceClient) ReactivateMember(id string) error {
+	var request = map[string]string{"id": id}
+
+	_, err := space.client.sendRequest("POST", "/api/space/members?action=reactivate", request)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type compRequest struct {
+	Id    string `json:"id"`
+	Plan  string `json:"plan"`
+	Until string `json:"until,omitempty"`
+}
+
+// Gives a member a plan for free until the given time, or indefinitely if
+// until is the zero time.  The member is not charged for the plan.
+func (space *SpaceClient) GrantComplimentaryPlan(memberID string, planID string, until time.Time) error {
+	var request = compRequest{Id: memberID, Plan: planID}
+	if !until.IsZero() {
+		request.Until = until.UTC().Format(time.RFC3339)
+	}
+
+	_, err := space.client.sendRequest("POST", "/api/space/members?action=comp", request)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package flexkit
+
+const getSpaceQuery string = `
+query getSpace($publicKey: String) {
+  space(publicKey: $publicKey) {
+    name,
+    slug,
+    logoutUrl,
+    currency,
+    plans {
+      alias,
+      name,
+      description,
+      amount,
+      interval
+    },
+    dataFields {
+      id,
+      label,
+      type,
+      required,
+      options
+    },
+    shippingOptions {
+      id,
+      name,
+      amount
+    }
+  }
+}`
+
+// Settings of a space
+type Space struct {
+	Name            string                // Name of the space
+	Slug            string                // Slug used in Plasso urls
+	LogoutURL       string                // Where members are sent after logging out
+	Currency        string                // ISO 4217 currency code, e.g. "USD"
+	Plans           []Plan                // Plans members can subscribe to
+	DataFields      []DataFieldDefinition // Custom data fields collected from members
+	ShippingOptions []ShippingOption      // Shipping options offered at checkout
+}
+
+// A plan members can subscribe to
+type Plan struct {
+	Id          string // Plan ID, as used in SubscriptionRequest.Plan
+	Name        string // Display name
+	Description string // Description shown to customers
+	Amount      Money  // Price per interval
+	Interval    string // Billing interval, e.g. "month"
+}
+
+// Describes a custom data field of a space
+type DataFieldDefinition struct {
+	Id       string   `json:"id"`       // Id used in DataItem.Id
+	Label    string   `json:"label"`    // Label shown to customers
+	Type     string   `json:"type"`     // Kind of value, e.g. "text" or "checkbox"
+	Required bool     `json:"required"` // Whether a value must be provided
+	Options  []string `json:"options"`  // Allowed values for select fields
+}
+
+// A shipping option offered at checkout
+type ShippingOption struct {
+	Id     string // Value for ShippingOptions in requests
+	Name   string // Display name
+	Amount Money  // Price of the option
+}
+
+type spaceResponse struct {
+	Space *struct {
+		Name      string `json:"name"`
+		Slug      string `json:"slug"`
+		LogoutUrl string `json:"logoutUrl"`
+		Currency  string `json:"currency"`
+		Plans     []struct {
+			Alias       string `json:"alias"`
+			Name        string `json:"name"`
+			Description string `json:"description"`
+			Amount      string `json:"amount"`
+			Interval    string `json:"interval"`
+		} `json:"plans"`
+		DataFields      []DataFieldDefinition `json:"dataFields"`
+		ShippingOptions []struct {
+			Id     string `json:"id"`
+			Name   string `json:"name"`
+			Amount string `json:"amount"`
+		} `json:"shippingOptions"`
+	} `json:"space"`
+}
+
+// Get the settings of the space set with WithPublicKey.  Plans and shipping
+// options whose amount cannot be read are left out and logged with WithLogger.
+func (c *Client) GetSpace() (*Space, error) {
+	var response spaceResponse
+	var variables = map[string]interface{}{"publicKey": c.getPublicKey()}
+
+	var err = c.GraphQL(c.context(), getSpaceQuery, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Space == nil {
+		return nil, ErrSpaceNotFound
+	}
+
+	var space = Space{
+		Name:       response.Space.Name,
+		Slug:       response.Space.Slug,
+		LogoutURL:  response.Space.LogoutUrl,
+		Currency:   response.Space.Currency,
+		DataFields: response.Space.DataFields,
+	}
+
+	for _, plan := range response.Space.Plans {
+		var amount Money
+		var err = parseAmount(plan.Amount, space.Currency, &amount)
+		if err != nil {
+			c.logSkipped("plan", plan.Alias, err)
+			continue
+		}
+
+		space.Plans = append(space.Plans, Plan{
+			Id:          plan.Alias,
+			Name:        plan.Name,
+			Description: plan.Description,
+			Amount:      amount,
+			Interval:    plan.Interval,
+		})
+	}
+
+	for _, option := range response.Space.ShippingOptions {
+		var amount Money
+		var err = parseAmount(option.Amount, space.Currency, &amount)
+		if err != nil {
+			c.logSkipped("shipping option", option.Id, err)
+			continue
+		}
+
+		space.ShippingOptions = append(space.ShippingOptions, ShippingOption{
+			Id:     option.Id,
+			Name:   option.Name,
+			Amount: amount,
+		})
+	}
+
+	return &space, nil
+}
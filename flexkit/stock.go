@@ -0,0 +1,82 @@
+package flexkit
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// Matches errors returned when products of a payment are out of stock, use
+// errors.As with an OutOfStockError to get the products
+var ErrOutOfStock = errors.New("flexkit: out of stock")
+
+const checkStockQuery string = `
+query checkStock($publicKey: String, $productIds: [String]) {
+  stock(publicKey: $publicKey, productIds: $productIds) {
+    productId,
+    available,
+    unlimited
+  }
+}`
+
+// Returned by CreatePayment and AuthorizePayment when products are out of stock.
+// The customer is not charged.
+type OutOfStockError struct {
+	ProductIds []string // The products that are out of stock
+	Err        error    // The *APIError of the response
+}
+
+func (err *OutOfStockError) Error() string {
+	return "flexkit: out of stock: " + strings.Join(err.ProductIds, ", ")
+}
+
+func (err *OutOfStockError) Is(target error) bool {
+	return target == ErrOutOfStock
+}
+
+func (err *OutOfStockError) Unwrap() error {
+	return err.Err
+}
+
+// The stock of a product
+type StockLevel struct {
+	ProductId string `json:"productId"` // Plasso product id
+	Available int    `json:"available"` // Units that can be bought
+	Unlimited bool   `json:"unlimited"` // Whether the product does not track stock
+}
+
+// Whether quantity units of the product can be bought
+func (level StockLevel) InStock(quantity int) bool {
+	return level.Unlimited || level.Available >= quantity
+}
+
+// Get the stock of products of the space set with WithPublicKey, e.g. to
+// disable buying products that are sold out
+func (c *Client) CheckStock(productIDs []string) ([]StockLevel, error) {
+	var response struct {
+		Stock []StockLevel `json:"stock"`
+	}
+	var variables = map[string]interface{}{"publicKey": c.getPublicKey(), "productIds": productIDs}
+
+	var err = c.GraphQL(c.context(), checkStockQuery, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Stock, nil
+}
+
+// Returns an OutOfStockError if an error response reports products out of
+// stock, otherwise err
+func stockError(body []byte, err error) error {
+	var response struct {
+		Error      string   `json:"error"`
+		ProductIds []string `json:"product_ids"`
+	}
+
+	if json.Unmarshal(body, &response) != nil || response.Error != "out_of_stock" {
+		return err
+	}
+
+	return &OutOfStockError{ProductIds: response.ProductIds, Err: err}
+}
@@ -0,0 +1,126 @@
+package flexkit
+
+import (
+	"fmt"
+	"time"
+)
+
+// Statuses of a member's subscription
+const (
+	SubscriptionActive    = "active"    // The member is paying for their plan
+	SubscriptionTrialing  = "trialing"  // The member is in the trial of their plan
+	SubscriptionPastDue   = "past_due"  // The latest payment failed and is being retried
+	SubscriptionPaused    = "paused"    // Billing is paused with PauseSubscription
+	SubscriptionCancelled = "cancelled" // The subscription ended
+)
+
+// How a quantity change is billed
+const (
+	ProrateCreate        = "create_prorations" // Credits or charges the difference on the next invoice
+	ProrateAlwaysInvoice = "always_invoice"    // Invoices the difference immediately
+	ProrateNone          = "none"              // The new quantity is billed from the next renewal
+)
+
+type quantityOptions struct {
+	proration string
+}
+
+// An option for UpdateSubscriptionQuantity
+type QuantityOption func(options *quantityOptions)
+
+// Sets how UpdateSubscriptionQuantity bills the change, one of the Prorate*
+// behaviors.  The space's default is used without this option.
+func Prorate(behavior string) QuantityOption {
+	return func(options *quantityOptions) {
+		options.proration = behavior
+	}
+}
+
+type quantityRequest struct {
+	Quantity  int    `json:"quantity"`
+	Proration string `json:"proration_behavior,omitempty"`
+
+	MemberToken string `json:"pltoken"`
+}
+
+type pauseRequest struct {
+	ResumeAt *time.Time `json:"resume_at,omitempty"`
+
+	MemberToken string `json:"pltoken"`
+}
+
+// Pauses billing of the member's subscription.  The subscription resumes at
+// until, or stays paused until ResumeSubscription is called when until is nil.
+func (member *Member) PauseSubscription(until *time.Time) error {
+	var request = pauseRequest{ResumeAt: until, MemberToken: member.TokenValue()}
+
+	_, err := member.getClient().sendRequest("POST", "/api/services/user?action=pause", request)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Resumes billing of a paused subscription
+func (member *Member) ResumeSubscription() error {
+	var request = pauseRequest{MemberToken: member.TokenValue()}
+
+	_, err := member.getClient().sendRequest("POST", "/api/services/user?action=resume", request)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type retryPaymentRequest struct {
+	MemberToken string `json:"pltoken"`
+}
+
+// Charges the failed latest payment of a past due member again, e.g. right
+// after they updated their card.  Returns an ActionRequiredError when the
+// customer has to complete an authentication challenge.
+func (member *Member) RetryLatestPayment() (*PaymentIntent, error) {
+	var client = member.getClient()
+	var request = retryPaymentRequest{MemberToken: member.TokenValue()}
+
+	body, err := client.sendRequest("POST", "/api/services/user?action=retry_payment", request)
+	if err != nil {
+		return nil, err
+	}
+
+	var intent = &PaymentIntent{publicKey: member.PublicKey, client: client}
+	err = intent.update(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if intent.Status == PaymentRequiresAction {
+		return nil, &ActionRequiredError{intent}
+	}
+
+	return intent, nil
+}
+
+// Changes the number of seats of the member's subscription, e.g. when the
+// size of their team changes
+func (member *Member) UpdateSubscriptionQuantity(quantity int, options ...QuantityOption) error {
+	if quantity < 1 {
+		return fmt.Errorf("flexkit: invalid quantity %d", quantity)
+	}
+
+	var quantityOptions quantityOptions
+	for _, option := range options {
+		option(&quantityOptions)
+	}
+
+	var request = quantityRequest{Quantity: quantity, Proration: quantityOptions.proration, MemberToken: member.TokenValue()}
+
+	_, err := member.getClient().sendRequest("POST", "/api/services/user?action=quantity", request)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
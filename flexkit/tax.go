@@ -0,0 +1,161 @@
+package flexkit
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Types of tax ids
+const (
+	TaxIDEUVAT = "eu_vat" // VAT number of an EU business
+	TaxIDGBVAT = "gb_vat" // VAT number of a UK business
+)
+
+// Formats of VAT numbers, keyed by country prefix
+var vatPatterns = map[string]*regexp.Regexp{
+	"AT": regexp.MustCompile(`^U\d{8}$`),
+	"BE": regexp.MustCompile(`^[01]\d{9}$`),
+	"BG": regexp.MustCompile(`^\d{9,10}$`),
+	"CY": regexp.MustCompile(`^\d{8}[A-Z]$`),
+	"CZ": regexp.MustCompile(`^\d{8,10}$`),
+	"DE": regexp.MustCompile(`^\d{9}$`),
+	"DK": regexp.MustCompile(`^\d{8}$`),
+	"EE": regexp.MustCompile(`^\d{9}$`),
+	"EL": regexp.MustCompile(`^\d{9}$`),
+	"ES": regexp.MustCompile(`^[A-Z0-9]\d{7}[A-Z0-9]$`),
+	"FI": regexp.MustCompile(`^\d{8}$`),
+	"FR": regexp.MustCompile(`^[A-HJ-NP-Z0-9]{2}\d{9}$`),
+	"GB": regexp.MustCompile(`^(\d{9}|\d{12}|GD\d{3}|HA\d{3})$`),
+	"HR": regexp.MustCompile(`^\d{11}$`),
+	"HU": regexp.MustCompile(`^\d{8}$`),
+	"IE": regexp.MustCompile(`^\d[A-Z0-9+*]\d{5}[A-W][A-I]?$`),
+	"IT": regexp.MustCompile(`^\d{11}$`),
+	"LT": regexp.MustCompile(`^(\d{9}|\d{12})$`),
+	"LU": regexp.MustCompile(`^\d{8}$`),
+	"LV": regexp.MustCompile(`^\d{11}$`),
+	"MT": regexp.MustCompile(`^\d{8}$`),
+	"NL": regexp.MustCompile(`^\d{9}B\d{2}$`),
+	"PL": regexp.MustCompile(`^\d{10}$`),
+	"PT": regexp.MustCompile(`^\d{9}$`),
+	"RO": regexp.MustCompile(`^\d{2,10}$`),
+	"SE": regexp.MustCompile(`^\d{12}$`),
+	"SI": regexp.MustCompile(`^\d{8}$`),
+	"SK": regexp.MustCompile(`^\d{10}$`),
+	"XI": regexp.MustCompile(`^(\d{9}|\d{12}|GD\d{3}|HA\d{3})$`),
+}
+
+// The structure that should be filled out and passed to the EstimateTax function.
+// Set either Plan or Products.
+type TaxRequest struct {
+	Plan      string    // The plan id to estimate tax for
+	Products  []Product // The products to estimate tax for
+	Address   Address   // Billing address of the customer
+	TaxID     string    // Tax id of a business customer (optional)
+	TaxIDType string    // One of the TaxID* types (optional)
+}
+
+// Tax that would be charged for a plan or products
+type TaxEstimate struct {
+	Subtotal      Money     // Total before tax
+	Tax           Money     // Total tax
+	Total         Money     // Total including tax
+	Lines         []TaxLine // The individual taxes
+	ReverseCharge bool      // Whether the customer accounts for VAT themselves
+}
+
+// A single tax of a TaxEstimate
+type TaxLine struct {
+	Name         string  // Name of the tax, e.g. "VAT"
+	Jurisdiction string  // Where the tax applies, e.g. "DE"
+	Rate         float64 // Rate in percent
+	Amount       Money   // Amount of tax
+}
+
+type taxRequest struct {
+	PublicKey string    `json:"public_key"`
+	Plan      string    `json:"plan,omitempty"`
+	Products  []Product `json:"products,omitempty"`
+	TaxID     string    `json:"tax_id"`
+	TaxIDType string    `json:"tax_id_type"`
+	billingFields
+}
+
+type taxResponse struct {
+	Currency      string `json:"currency"`
+	Subtotal      string `json:"subtotal"`
+	Tax           string `json:"tax"`
+	Total         string `json:"total"`
+	ReverseCharge bool   `json:"reverse_charge"`
+	Lines         []struct {
+		Name         string  `json:"name"`
+		Jurisdiction string  `json:"jurisdiction"`
+		Rate         float64 `json:"rate"`
+		Amount       string  `json:"amount"`
+	} `json:"lines"`
+}
+
+// Estimates the tax for a plan or products in the space set with WithPublicKey,
+// so prices can be shown including tax before checkout.
+func (c *Client) EstimateTax(request TaxRequest) (*TaxEstimate, error) {
+	var wire = taxRequest{
+		PublicKey:     c.getPublicKey(),
+		Plan:          request.Plan,
+		Products:      request.Products,
+		TaxID:         request.TaxID,
+		TaxIDType:     request.TaxIDType,
+		billingFields: request.Address.billingFields(),
+	}
+
+	body, err := c.sendRequest("POST", "/api/tax/estimate", wire)
+	if err != nil {
+		return nil, err
+	}
+
+	var response taxResponse
+	err = c.decode(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	var estimate = TaxEstimate{ReverseCharge: response.ReverseCharge}
+	err = errors.Join(
+		parseAmount(response.Subtotal, response.Currency, &estimate.Subtotal),
+		parseAmount(response.Tax, response.Currency, &estimate.Tax),
+		parseAmount(response.Total, response.Currency, &estimate.Total))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range response.Lines {
+		var taxLine = TaxLine{Name: line.Name, Jurisdiction: line.Jurisdiction, Rate: line.Rate}
+		err = parseAmount(line.Amount, response.Currency, &taxLine.Amount)
+		if err != nil {
+			return nil, err
+		}
+		estimate.Lines = append(estimate.Lines, taxLine)
+	}
+
+	return &estimate, nil
+}
+
+// Checks the format of a VAT number such as "DE123456789".  Spaces, dots and
+// dashes are ignored.  This does not check the number is registered.
+func ValidateVATID(id string) error {
+	var normalized = strings.ToUpper(strings.NewReplacer(" ", "", ".", "", "-", "").Replace(id))
+	if len(normalized) < 3 {
+		return fmt.Errorf("flexkit: invalid VAT number %q", id)
+	}
+
+	var pattern, ok = vatPatterns[normalized[:2]]
+	if !ok {
+		return fmt.Errorf("flexkit: unknown VAT number country %q", normalized[:2])
+	}
+
+	if !pattern.MatchString(normalized[2:]) {
+		return fmt.Errorf("flexkit: invalid VAT number %q", id)
+	}
+
+	return nil
+}
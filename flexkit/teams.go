@@ -0,0 +1,130 @@
+package flexkit
+
+import "errors"
+
+// Returned by GetTeam when the member is not part of a team
+var ErrNoTeam = errors.New("flexkit: member has no team")
+
+const getTeamQuery string = `
+query getTeam($token: String) {
+  member(token: $token) {
+    team {
+      id,
+      name,
+      ownerId,
+      members {
+        id,
+        email,
+        name,
+        pending
+      }
+    }
+  }
+}`
+
+// A team of members sharing the plan of the member who owns it
+type Team struct {
+	Id      string       `json:"id"`      // Plasso team id
+	Name    string       `json:"name"`    // Name of the team
+	OwnerId string       `json:"ownerId"` // Id of the paying member
+	Members []TeamMember `json:"members"` // Members of the team, including the owner and pending invitations
+
+	member *Member
+}
+
+// A member of a team
+type TeamMember struct {
+	Id      string `json:"id"`      // Member id, empty for pending invitations
+	Email   string `json:"email"`   // Email the member was invited with
+	Name    string `json:"name"`    // Name of the member
+	Pending bool   `json:"pending"` // Whether the invitation has not been accepted yet
+}
+
+type teamRequest struct {
+	Id    string `json:"id,omitempty"`
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+
+	MemberToken string `json:"pltoken"`
+}
+
+// Creates a team owned by the member.  Teammates share the member's plan.
+func (member *Member) CreateTeam(name string) (*Team, error) {
+	var request = teamRequest{Name: name, MemberToken: member.TokenValue()}
+
+	body, err := member.getClient().sendRequest("POST", "/api/services/team?action=create", request)
+	if err != nil {
+		return nil, err
+	}
+
+	var team = &Team{member: member}
+	err = member.getClient().decode(body, team)
+	if err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// Get the team the member owns or belongs to.  Returns ErrNoTeam when the
+// member is not part of a team.
+func (member *Member) GetTeam() (*Team, error) {
+	var response struct {
+		Member struct {
+			Team *Team `json:"team"`
+		} `json:"member"`
+	}
+	var variables = map[string]interface{}{"token": member.TokenValue()}
+
+	var err = member.getClient().GraphQL(member.context(), getTeamQuery, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Member.Team == nil {
+		return nil, ErrNoTeam
+	}
+
+	response.Member.Team.member = member
+	return response.Member.Team, nil
+}
+
+// Invites someone to the team by email.  Only the owner can invite.
+func (team *Team) Invite(email string) (*TeamMember, error) {
+	var request = teamRequest{Id: team.Id, Email: email, MemberToken: team.member.TokenValue()}
+
+	body, err := team.member.getClient().sendRequest("POST", "/api/services/team?action=invite", request)
+	if err != nil {
+		return nil, err
+	}
+
+	var invited TeamMember
+	err = team.member.getClient().decode(body, &invited)
+	if err != nil {
+		return nil, err
+	}
+
+	team.Members = append(team.Members, invited)
+	return &invited, nil
+}
+
+// Removes a member or pending invitation from the team by email.  Only the
+// owner can remove others, members can remove themselves.
+func (team *Team) Remove(email string) error {
+	var request = teamRequest{Id: team.Id, Email: email, MemberToken: team.member.TokenValue()}
+
+	_, err := team.member.getClient().sendRequest("POST", "/api/services/team?action=remove", request)
+	if err != nil {
+		return err
+	}
+
+	var members = team.Members[:0]
+	for _, teamMember := range team.Members {
+		if teamMember.Email != email {
+			members = append(members, teamMember)
+		}
+	}
+	team.Members = members
+
+	return nil
+}
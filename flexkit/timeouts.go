@@ -0,0 +1,80 @@
+package flexkit
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const defaultTimeout = 30 * time.Second
+const defaultGraphQLTimeout = 15 * time.Second
+
+// Cause of the contexts of calls that ran out of the client's own timeout, as
+// opposed to a deadline set by the caller
+var errClientTimeout = errors.New("flexkit: client timeout")
+
+// Sets how long REST calls may take, 30 seconds by default
+func WithTimeout(timeout time.Duration) Option {
+	return func(client *Client) {
+		client.timeout = timeout
+	}
+}
+
+// Sets how long GraphQL calls such as GetData may take, 15 seconds by default
+func WithGraphQLTimeout(timeout time.Duration) Option {
+	return func(client *Client) {
+		client.graphQLTimeout = timeout
+	}
+}
+
+// Returns a copy of the client whose calls use ctx, so they are canceled with
+// it.  The deadline of ctx, if it has one, replaces the timeouts of the client
+// for those calls.
+//
+//	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+//	defer cancel()
+//	err = client.WithContext(ctx).CreatePayment(request)
+func (c *Client) WithContext(ctx context.Context) *Client {
+	var client = *c
+	client.ctx = ctx
+	return &client
+}
+
+// Returns a copy of the member whose calls use ctx, see Client.WithContext
+func (member *Member) WithContext(ctx context.Context) *Member {
+	return member.getClient().WithContext(ctx).NewMember(member.PublicKey, member.TokenValue())
+}
+
+// Returns a copy of the space client whose calls use ctx, see Client.WithContext
+func (space *SpaceClient) WithContext(ctx context.Context) *SpaceClient {
+	return &SpaceClient{space.client.WithContext(ctx)}
+}
+
+// The context calls of the client are made with
+func (c *Client) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+
+	return c.ctx
+}
+
+func (member *Member) context() context.Context {
+	return member.getClient().context()
+}
+
+// Applies timeout to ctx unless ctx already has a deadline
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeoutCause(ctx, timeout, errClientTimeout)
+}
+
+// Whether the caller gave up on a call made with ctx, by canceling it or
+// through their own deadline.  Running out of the client's timeout is not the
+// caller's doing.
+func canceledByCaller(ctx context.Context) bool {
+	return ctx.Err() != nil && context.Cause(ctx) != errClientTimeout
+}
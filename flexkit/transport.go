@@ -0,0 +1,47 @@
+package flexkit
+
+import (
+	"net/http"
+	"time"
+)
+
+// Returns a copy of the default transport for the client to tune
+func newTransport() *http.Transport {
+	if transport, ok := http.DefaultTransport.(*http.Transport); ok {
+		return transport.Clone()
+	}
+
+	return &http.Transport{Proxy: http.ProxyFromEnvironment}
+}
+
+// Sends requests with roundTripper instead of the transport of the client,
+// e.g. to record calls in tests.  The other transport options have no effect
+// then.
+func WithRoundTripper(roundTripper http.RoundTripper) Option {
+	return func(client *Client) {
+		client.roundTripper = roundTripper
+	}
+}
+
+// Sets how many idle connections to the API are kept open for reuse, 2 by
+// default.  Raise it for servers making many concurrent calls.
+func WithMaxIdleConnsPerHost(conns int) Option {
+	return func(client *Client) {
+		client.transport.MaxIdleConnsPerHost = conns
+	}
+}
+
+// Sets how long an idle connection to the API is kept open, 90 seconds by
+// default
+func WithIdleConnTimeout(timeout time.Duration) Option {
+	return func(client *Client) {
+		client.transport.IdleConnTimeout = timeout
+	}
+}
+
+// Stops asking the API for gzip compressed responses
+func WithDisableCompression() Option {
+	return func(client *Client) {
+		client.transport.DisableCompression = true
+	}
+}
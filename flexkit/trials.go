@@ -0,0 +1,34 @@
+package flexkit
+
+import (
+	"math"
+	"time"
+)
+
+// The trial of a member
+type TrialStatus struct {
+	Active        bool      // Whether the member is in their trial
+	End           time.Time // When the trial ends, zero if the member never had a trial
+	DaysRemaining int       // Days left in the trial, rounded up, 0 once it ended
+}
+
+// Returns the trial of the member, e.g. for showing how many days are left
+func (member *Member) TrialStatus() (*TrialStatus, error) {
+	var memberData, err = member.GetData(Fields("trialEnd"))
+	if err != nil {
+		return nil, err
+	}
+
+	return trialStatus(memberData.TrialEnd, time.Now()), nil
+}
+
+func trialStatus(end time.Time, now time.Time) *TrialStatus {
+	var status = &TrialStatus{End: end}
+	if end.IsZero() || !end.After(now) {
+		return status
+	}
+
+	status.Active = true
+	status.DaysRemaining = int(math.Ceil(end.Sub(now).Hours() / 24))
+	return status
+}
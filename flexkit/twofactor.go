@@ -0,0 +1,97 @@
+package flexkit
+
+import "errors"
+
+// Matches errors returned by Login for members with two-factor authentication
+// enabled, use errors.As with a TwoFactorRequiredError to get the challenge
+var ErrTwoFactorRequired = errors.New("flexkit: two-factor authentication required")
+
+// Returned by Login when the password was right but the member has
+// two-factor authentication enabled.  Ask them for the code from their
+// authenticator app and pass it to CompleteLogin with Challenge.
+type TwoFactorRequiredError struct {
+	Challenge string // Handle of the pending login, valid for a few minutes
+}
+
+func (err *TwoFactorRequiredError) Error() string {
+	return "flexkit: two-factor authentication required"
+}
+
+func (err *TwoFactorRequiredError) Is(target error) bool {
+	return target == ErrTwoFactorRequired
+}
+
+type loginResponse struct {
+	Token     string `json:"token"`
+	Challenge string `json:"two_factor_challenge"` // Set instead of Token when a code is needed
+}
+
+type completeLoginRequest struct {
+	PublicKey string `json:"public_key"`
+	Challenge string `json:"challenge"`
+	Code      string `json:"code"`
+}
+
+type twoFactorRequest struct {
+	Code        string `json:"code,omitempty"`
+	MemberToken string `json:"pltoken"`
+}
+
+type enableTwoFactorResponse struct {
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// Finishes a login that returned a TwoFactorRequiredError with the code from
+// the member's authenticator app
+func (c *Client) CompleteLogin(challenge string, code string) (*Member, error) {
+	var request = completeLoginRequest{PublicKey: c.getPublicKey(), Challenge: challenge, Code: code}
+
+	body, err := c.sendRequest("POST", "/api/service/login/2fa", request)
+	if err != nil {
+		return nil, lockoutError(body, err)
+	}
+
+	var r tokenResponse
+	err = c.decode(body, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.NewMember(request.PublicKey, r.Token), nil
+}
+
+// Starts enrolling the member in two-factor authentication.  Show the returned
+// otpauth:// URI as a QR code for their authenticator app, two-factor
+// authentication is only enabled once Confirm2FA is called with a code from
+// the app.
+func (member *Member) Enable2FA() (string, error) {
+	var client = member.getClient()
+	var request = twoFactorRequest{MemberToken: member.TokenValue()}
+
+	body, err := client.sendRequest("POST", "/api/services/user?action=2fa_enable", request)
+	if err != nil {
+		return "", err
+	}
+
+	var r enableTwoFactorResponse
+	err = client.decode(body, &r)
+	if err != nil {
+		return "", err
+	}
+
+	return r.ProvisioningURI, nil
+}
+
+// Enables two-factor authentication started with Enable2FA, using a code from
+// the member's authenticator app to prove it was set up.  From then on Login
+// returns a TwoFactorRequiredError for the member.
+func (member *Member) Confirm2FA(code string) error {
+	var request = twoFactorRequest{Code: code, MemberToken: member.TokenValue()}
+
+	_, err := member.getClient().sendRequest("POST", "/api/services/user?action=2fa_confirm", request)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
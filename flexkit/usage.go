@@ -0,0 +1,42 @@
+package flexkit
+
+import (
+	"net/http"
+	"time"
+)
+
+type usageRequest struct {
+	Id        string    `json:"id"`
+	Metric    string    `json:"metric"`
+	Quantity  int64     `json:"quantity"`
+	Timestamp time.Time `json:"timestamp"`
+
+	MemberToken string `json:"pltoken"`
+}
+
+// Reports usage of a metered metric of the member's plan, such as "api_calls",
+// and returns the id of the record.  Reports with the same id are only counted
+// once, so send a failed report again with the id it returned, or pass an id
+// of your own such as that of the event being billed.  An empty id generates a
+// new one.
+func (member *Member) ReportUsage(id string, metric string, quantity int64, at time.Time) (string, error) {
+	if id == "" {
+		id = newIdempotencyKey()
+	}
+
+	var request = usageRequest{
+		Id:          id,
+		Metric:      metric,
+		Quantity:    quantity,
+		Timestamp:   at.UTC(),
+		MemberToken: member.TokenValue(),
+	}
+	var header = http.Header{"Idempotency-Key": {request.Id}}
+
+	_, err := member.getClient().send(member.context(), "POST", "/api/services/user?action=usage", header, request)
+	if err != nil {
+		return id, err
+	}
+
+	return id, nil
+}
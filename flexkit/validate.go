@@ -0,0 +1,145 @@
+package flexkit
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+)
+
+// Checks the request has everything Login needs.  Every problem is reported,
+// joined into one error.  PublicKey is not checked, the client fills it in
+// when it is empty.
+func (request LoginRequest) Validate() error {
+	var errs []error
+
+	errs = append(errs, validateEmail("login", request.Email, true))
+	if request.Password == "" {
+		errs = append(errs, errors.New("login: password is required"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Checks the request has everything CreatePayment needs and that the parts
+// that are set are well formed.  Every problem is reported, joined into one
+// error.  PublicKey is not checked, the client fills it in when it is empty.
+func (request PaymentRequest) Validate() error {
+	var errs []error
+
+	if request.Token == "" {
+		errs = append(errs, errors.New("payment: token is required"))
+	}
+	if len(request.Products) == 0 {
+		errs = append(errs, errors.New("payment: at least one product is required"))
+	}
+	for i, product := range request.Products {
+		if product.Id == "" {
+			errs = append(errs, fmt.Errorf("payment: product %d has no id", i))
+		}
+	}
+	errs = append(errs,
+		validateEmail("payment", request.Email, true),
+		validateAddress("payment: billing", request.Billing),
+		validateAddress("payment: shipping", request.Shipping),
+		validateTaxID(request.TaxID, request.TaxIDType))
+
+	return errors.Join(errs...)
+}
+
+// Checks the request has everything CreateSubscription needs and that the
+// parts that are set are well formed.  Every problem is reported, joined into
+// one error.  PublicKey is not checked, the client fills it in when it is
+// empty.
+func (request SubscriptionRequest) Validate() error {
+	var errs []error
+
+	if request.Plan == "" {
+		errs = append(errs, errors.New("subscription: plan is required"))
+	}
+	if request.Token == "" {
+		errs = append(errs, errors.New("subscription: token is required"))
+	}
+	if request.Password == "" {
+		errs = append(errs, errors.New("subscription: password is required"))
+	}
+	if request.TrialDays < 0 {
+		errs = append(errs, errors.New("subscription: trial days cannot be negative"))
+	}
+	errs = append(errs,
+		validateEmail("subscription", request.Email, true),
+		validateAddress("subscription: billing", request.Billing),
+		validateAddress("subscription: shipping", request.Shipping),
+		validateTaxID(request.TaxID, request.TaxIDType))
+
+	return errors.Join(errs...)
+}
+
+// Checks the request has everything CreateFreeSubscription needs.  Every
+// problem is reported, joined into one error.  PublicKey is not checked, the
+// client fills it in when it is empty.
+func (request FreeSubscriptionRequest) Validate() error {
+	var errs []error
+
+	if request.Plan == "" {
+		errs = append(errs, errors.New("subscription: plan is required"))
+	}
+	if request.Password == "" {
+		errs = append(errs, errors.New("subscription: password is required"))
+	}
+	errs = append(errs, validateEmail("subscription", request.Email, true))
+
+	return errors.Join(errs...)
+}
+
+// Checks the parts of the request that are set are well formed.  Every problem
+// is reported, joined into one error.
+func (request SettingsRequest) Validate() error {
+	return errors.Join(
+		validateEmail("settings", request.Email, false),
+		validateAddress("settings: shipping", request.Shipping))
+}
+
+// Checks email is a plain address such as "jane@example.com"
+func validateEmail(prefix string, email string, required bool) error {
+	if email == "" {
+		if required {
+			return fmt.Errorf("%s: email is required", prefix)
+		}
+		return nil
+	}
+
+	var address, err = mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return fmt.Errorf("%s: invalid email %q", prefix, email)
+	}
+
+	return nil
+}
+
+// Validates address unless it is not set at all, prefixing every problem
+func validateAddress(prefix string, address Address) error {
+	if address.IsZero() {
+		return nil
+	}
+
+	var joined, ok = address.Validate().(interface{ Unwrap() []error })
+	if !ok {
+		return nil
+	}
+
+	var errs []error
+	for _, err := range joined.Unwrap() {
+		errs = append(errs, fmt.Errorf("%s %w", prefix, err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Checks the format of VAT numbers, other tax ids are checked by the server
+func validateTaxID(id string, kind string) error {
+	if id == "" || (kind != TaxIDEUVAT && kind != TaxIDGBVAT) {
+		return nil
+	}
+
+	return ValidateVATID(id)
+}
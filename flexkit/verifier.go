@@ -0,0 +1,382 @@
+package flexkit
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Returned by TokenVerifier.Verify for tokens that are malformed, expired,
+// signed with an unknown key or not accepted by the API
+var ErrInvalidToken = errors.New("flexkit: invalid member token")
+
+const validateTokenQuery string = `
+query validateToken($token: String) {
+  member(token: $token) {
+    id
+  }
+}`
+
+// How long fetched signing keys are used before they are fetched again
+const keysMaxAge = time.Hour
+
+// Shortest time between fetches for tokens signed with an unknown key
+const keysMinAge = time.Minute
+
+// Allowed difference between the clocks of Plasso and this machine
+const tokenLeeway = time.Minute
+
+// What a verified member token says
+type TokenClaims struct {
+	MemberId  string    // Id of the member
+	PublicKey string    // Public key of the space the token was issued by, if known
+	IssuedAt  time.Time // Zero if unknown
+	ExpiresAt time.Time // Zero when checked with the API
+	Local     bool      // Whether the token was verified without calling the API
+}
+
+// Verifies member tokens, e.g. to authenticate requests to an app.  Tokens
+// that are JWTs are checked locally against the signing keys the space
+// publishes, which are fetched once and cached, so most calls to Verify make
+// no network call.  Other tokens, and JWTs without an expiry or audience, are
+// checked with the API.  It is safe for concurrent use.
+type TokenVerifier struct {
+	client *Client
+
+	fetching sync.Mutex                  // Held while keys are fetched
+	mutex    sync.Mutex                  // Guards keys and fetched
+	keys     map[string]crypto.PublicKey // keyed by key id
+	fetched  time.Time                   // When keys were fetched
+}
+
+type jwksRequest struct {
+	PublicKey string `json:"public_key"`
+}
+
+// A JSON Web Key Set as published by Plasso
+type jwksResponse struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg,omitempty"`
+	Use string `json:"use,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type jwtClaims struct {
+	Subject   string          `json:"sub"`
+	Audience  json.RawMessage `json:"aud"` // A string or a list of strings
+	IssuedAt  int64           `json:"iat"`
+	NotBefore int64           `json:"nbf"`
+	ExpiresAt int64           `json:"exp"`
+}
+
+// Creates a verifier for member tokens of the space set on client
+func NewTokenVerifier(client *Client) *TokenVerifier {
+	return &TokenVerifier{client: client}
+}
+
+// Checks that token is a valid member token of the space and returns what it
+// says.  Errors other than ErrInvalidToken mean the token could not be checked,
+// e.g. because the API is down.
+func (verifier *TokenVerifier) Verify(ctx context.Context, token string) (*TokenClaims, error) {
+	var parts = strings.Split(token, ".")
+	if len(parts) != 3 {
+		return verifier.verifyRemote(ctx, token)
+	}
+
+	var header jwtHeader
+	var err = decodeSegment(parts[0], &header)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := verifier.key(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	err = verifySignature(header.Alg, key, []byte(parts[0]+"."+parts[1]), signature)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims jwtClaims
+	err = decodeSegment(parts[1], &claims)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without an expiry and audience a token would be valid forever and for
+	// any space, only the API can tell whether it still is
+	if claims.ExpiresAt == 0 || len(claims.Audience) == 0 || verifier.client.getPublicKey() == "" {
+		return verifier.verifyRemote(ctx, token)
+	}
+
+	return verifier.checkClaims(claims)
+}
+
+// Checks the time and audience of verified claims
+func (verifier *TokenVerifier) checkClaims(claims jwtClaims) (*TokenClaims, error) {
+	var now = time.Now()
+	if claims.ExpiresAt == 0 || now.After(time.Unix(claims.ExpiresAt, 0).Add(tokenLeeway)) {
+		return nil, fmt.Errorf("%w: expired", ErrInvalidToken)
+	}
+	if claims.NotBefore != 0 && now.Add(tokenLeeway).Before(time.Unix(claims.NotBefore, 0)) {
+		return nil, fmt.Errorf("%w: not valid yet", ErrInvalidToken)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: no member", ErrInvalidToken)
+	}
+
+	var audience []string
+	if len(claims.Audience) > 0 && json.Unmarshal(claims.Audience, &audience) != nil {
+		var single string
+		if json.Unmarshal(claims.Audience, &single) != nil {
+			return nil, fmt.Errorf("%w: malformed audience", ErrInvalidToken)
+		}
+		audience = []string{single}
+	}
+
+	var publicKey = verifier.client.getPublicKey()
+	if publicKey == "" || !slices.Contains(audience, publicKey) {
+		return nil, fmt.Errorf("%w: issued for another space", ErrInvalidToken)
+	}
+
+	var result = &TokenClaims{
+		MemberId:  claims.Subject,
+		PublicKey: publicKey,
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0),
+		Local:     true,
+	}
+	if claims.IssuedAt != 0 {
+		result.IssuedAt = time.Unix(claims.IssuedAt, 0)
+	}
+
+	return result, nil
+}
+
+// Checks a token that is not a JWT with the API
+func (verifier *TokenVerifier) verifyRemote(ctx context.Context, token string) (*TokenClaims, error) {
+	var response struct {
+		Member *struct {
+			Id string `json:"id"`
+		} `json:"member"`
+	}
+	var variables = map[string]interface{}{"token": token}
+
+	var err = verifier.client.GraphQL(ctx, validateTokenQuery, variables, &response)
+	var graphQLErrors GraphQLErrors
+	if errors.As(err, &graphQLErrors) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if response.Member == nil || response.Member.Id == "" {
+		return nil, ErrInvalidToken
+	}
+
+	return &TokenClaims{MemberId: response.Member.Id, PublicKey: verifier.client.getPublicKey()}, nil
+}
+
+// Returns the signing key with the given id, fetching the keys when they are
+// old or do not include it.  Only one fetch runs at a time, calls that know
+// the key keep using the old keys meanwhile and while fetching fails.
+func (verifier *TokenVerifier) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	var key, ok, age = verifier.cached(kid)
+	if ok && age < keysMaxAge {
+		return key, nil
+	}
+
+	if ok && !verifier.fetching.TryLock() {
+		return key, nil
+	}
+	if !ok {
+		verifier.fetching.Lock()
+	}
+	defer verifier.fetching.Unlock()
+
+	// Another call may have fetched the keys while this one waited
+	key, ok, age = verifier.cached(kid)
+	if ok && age < keysMaxAge {
+		return key, nil
+	}
+
+	if age >= keysMinAge {
+		var keys, err = verifier.fetchKeys(ctx)
+		if err != nil && !ok {
+			return nil, err
+		}
+		if err == nil {
+			verifier.mutex.Lock()
+			verifier.keys = keys
+			verifier.fetched = time.Now()
+			verifier.mutex.Unlock()
+			key, ok = keys[kid]
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidToken, kid)
+	}
+
+	return key, nil
+}
+
+// Returns the cached key with the given id and the age of the cached keys
+func (verifier *TokenVerifier) cached(kid string) (crypto.PublicKey, bool, time.Duration) {
+	verifier.mutex.Lock()
+	defer verifier.mutex.Unlock()
+
+	var key, ok = verifier.keys[kid]
+	return key, ok, time.Since(verifier.fetched)
+}
+
+// Fetches the signing keys of the space
+func (verifier *TokenVerifier) fetchKeys(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	var client = verifier.client
+	var request = jwksRequest{PublicKey: client.getPublicKey()}
+
+	body, err := client.send(ctx, "POST", "/api/service/jwks", nil, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var response jwksResponse
+	err = client.decode(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys = map[string]crypto.PublicKey{}
+	for _, jwk := range response.Keys {
+		key, err := jwk.publicKey()
+		if err != nil {
+			if client.logger != nil {
+				client.logger.Warn("flexkit skipped signing key", "kid", jwk.Kid, "error", err)
+			}
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	return keys, nil
+}
+
+// Returns the key a JWK describes
+func (jwk jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		var exponent = new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+			return nil, errors.New("rsa exponent too large")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		if jwk.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+
+	return nil, fmt.Errorf("unsupported key type %q", jwk.Kty)
+}
+
+// Checks the signature of a JWT made with alg.  The algorithm must match the
+// type of key, so a token cannot pick a weaker check.
+func verifySignature(alg string, key crypto.PublicKey, signed []byte, signature []byte) error {
+	var digest = sha256.Sum256(signed)
+	var valid bool
+
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		valid = alg == "RS256" && rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil
+	case *ecdsa.PublicKey:
+		if alg == "ES256" && len(signature) == 64 {
+			var r = new(big.Int).SetBytes(signature[:32])
+			var s = new(big.Int).SetBytes(signature[32:])
+			valid = ecdsa.Verify(key, digest[:], r, s)
+		}
+	case ed25519.PublicKey:
+		valid = alg == "EdDSA" && ed25519.Verify(key, signed, signature)
+	}
+
+	if !valid {
+		return fmt.Errorf("%w: bad signature", ErrInvalidToken)
+	}
+
+	return nil
+}
+
+// Decodes a base64url JSON segment of a JWT
+func decodeSegment(segment string, out interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	err = json.Unmarshal(data, out)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package flexkit
+
+import (
+	"runtime"
+	"strings"
+)
+
+const version string = "0.1.0"
+
+// Returns the version of this package.  It is sent in the User-Agent header
+// of every request.
+func Version() string {
+	return version
+}
+
+// Adds the name and version of your app to the User-Agent header, which helps
+// Plasso support find your requests
+func WithAppInfo(name string, appVersion string) Option {
+	return func(client *Client) {
+		client.appInfo = strings.TrimSuffix(name+"/"+appVersion, "/")
+	}
+}
+
+// Returns the User-Agent header, e.g. "flexkit-go/0.1.0 (go1.22.1) myapp/2.0"
+func (c *Client) userAgent() string {
+	var userAgent = "flexkit-go/" + version + " (" + runtime.Version() + ")"
+	if c.appInfo != "" {
+		userAgent += " " + c.appInfo
+	}
+
+	return userAgent
+}
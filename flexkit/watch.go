@@ -0,0 +1,110 @@
+package flexkit
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// What changed about a member
+const (
+	ChangePlan       = "plan"        // The member changed plan
+	ChangeStatus     = "status"      // The subscription status changed, e.g. a payment failed
+	ChangeDataFields = "data_fields" // A data item changed
+)
+
+const defaultWatchInterval = time.Minute
+
+// A change of a member, sent by Watch
+type MemberChange struct {
+	Kind string      // One of the Change* kinds, empty when Err is set
+	Old  *MemberData // The member before the change
+	New  *MemberData // The member after the change
+	Err  error       // Why polling failed, Watch keeps polling after errors
+}
+
+type watchOptions struct {
+	interval time.Duration
+}
+
+// An option for Watch
+type WatchOption func(options *watchOptions)
+
+// Sets how often Watch fetches the member, once a minute by default.  An
+// interval that is not positive keeps the default.
+func WatchInterval(interval time.Duration) WatchOption {
+	return func(options *watchOptions) {
+		options.interval = interval
+	}
+}
+
+// Fetches the member periodically and sends a change whenever their plan,
+// subscription status or data fields change.  The channel is closed once ctx
+// is done.  Watch is meant for long running processes without webhooks.
+func (member *Member) Watch(ctx context.Context, options ...WatchOption) <-chan MemberChange {
+	var watchOptions = watchOptions{interval: defaultWatchInterval}
+	for _, option := range options {
+		option(&watchOptions)
+	}
+	if watchOptions.interval <= 0 {
+		watchOptions.interval = defaultWatchInterval
+	}
+
+	var changes = make(chan MemberChange)
+	go func() {
+		defer close(changes)
+
+		var ticker = time.NewTicker(watchOptions.interval)
+		defer ticker.Stop()
+
+		var last *MemberData
+		for {
+			var current, err = member.getData(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+
+			var found []MemberChange
+			if err != nil {
+				found = []MemberChange{{Err: err}}
+			} else if last != nil {
+				found = memberChanges(last, current)
+			}
+			if err == nil {
+				last = current
+			}
+
+			for _, change := range found {
+				select {
+				case changes <- change:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return changes
+}
+
+func memberChanges(before *MemberData, after *MemberData) []MemberChange {
+	var changes []MemberChange
+
+	if before.Plan != after.Plan {
+		changes = append(changes, MemberChange{Kind: ChangePlan, Old: before, New: after})
+	}
+	if before.Status != after.Status {
+		changes = append(changes, MemberChange{Kind: ChangeStatus, Old: before, New: after})
+	}
+	if !slices.Equal(before.DataFields, after.DataFields) {
+		changes = append(changes, MemberChange{Kind: ChangeDataFields, Old: before, New: after})
+	}
+
+	return changes
+}